@@ -0,0 +1,138 @@
+package qp
+
+import (
+	sqldriver "database/sql/driver"
+	"encoding/hex"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Array is a slice bound as a single parameter in postgresql array text format,
+// see the %a verb
+// Elements are integers, floats, strings, bools, bytes, times, nested slices or nil for NULL
+//		var query = qp.Format("SELECT name FROM users WHERE id = ANY(%p)", qp.Array{Slice: ids})
+//		_ = query.String() // SELECT name FROM users WHERE id = ANY($1)
+//		_ = query.Params() // [{1,2,3}]
+type Array struct {
+	Slice interface{}
+}
+
+var _ sqldriver.Valuer = Array{}
+
+// Value implements the driver.Valuer interface
+// A nil slice is NULL
+func (a Array) Value() (sqldriver.Value, error) {
+	var v = reflect.ValueOf(a.Slice)
+	if !v.IsValid() || (v.Kind() == reflect.Slice && v.IsNil()) {
+		return nil, nil
+	}
+	var b, err = appendArray(nil, v)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// String returns the array text
+func (a Array) String() string {
+	var v, err = a.Value()
+	if s, ok := v.(string); ok && err == nil {
+		return s
+	}
+	return "NULL"
+}
+
+// appendArray appends a slice in array text format
+//		[]interface{}{1, "a b", nil, []int{2}} => {1,"a b",NULL,{2}}
+func appendArray(b []byte, v reflect.Value) ([]byte, error) {
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, unsupported(v.Interface())
+	}
+	b = append(b, '{')
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		var err error
+		if b, err = appendElement(b, v.Index(i)); err != nil {
+			return nil, err
+		}
+	}
+	return append(b, '}'), nil
+}
+
+// appendElement appends an element of an array, strings are always quoted
+func appendElement(b []byte, v reflect.Value) ([]byte, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return append(b, "NULL"...), nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return append(b, "NULL"...), nil
+	}
+
+	switch x := v.Interface().(type) {
+	case time.Time:
+		return appendQuoted(b, x.Format("2006-01-02 15:04:05.999999-07:00")), nil
+	case []byte:
+		if x == nil {
+			return append(b, "NULL"...), nil
+		}
+		return appendQuoted(b, `\x`+hex.EncodeToString(x)), nil
+	case sqldriver.Valuer:
+		var y, err = x.Value()
+		if err != nil {
+			return nil, err
+		}
+		return appendElement(b, reflect.ValueOf(&y).Elem())
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return appendQuoted(b, v.String()), nil
+	case reflect.Bool:
+		if v.Bool() {
+			return append(b, 't'), nil
+		}
+		return append(b, 'f'), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(b, v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.AppendUint(b, v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		var f = v.Float()
+		switch {
+		case math.IsNaN(f):
+			return append(b, "NaN"...), nil
+		case math.IsInf(f, 1):
+			return append(b, "Infinity"...), nil
+		case math.IsInf(f, -1):
+			return append(b, "-Infinity"...), nil
+		}
+		return strconv.AppendFloat(b, f, 'g', -1, v.Type().Bits()), nil
+	case reflect.Slice, reflect.Array:
+		return appendArray(b, v)
+	}
+	return nil, unsupported(v.Interface())
+}
+
+// appendQuoted appends a double quoted element, quotes and backslashes are escaped with a backslash
+func appendQuoted(b []byte, s string) []byte {
+	b = append(b, '"')
+	for {
+		var i = strings.IndexAny(s, `"\`)
+		if i < 0 {
+			break
+		}
+		b = append(b, s[:i]...)
+		b = append(b, '\\', s[i])
+		s = s[i+1:]
+	}
+	b = append(b, s...)
+	return append(b, '"')
+}
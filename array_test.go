@@ -0,0 +1,68 @@
+package qp
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArray_Value(t *testing.T) {
+	var (
+		s    = "b"
+		null *string
+	)
+	var cases = []struct {
+		name  string
+		array Array
+		value interface{}
+	}{
+		{name: "case_ints", array: Array{Slice: []int{1, -2, 3}}, value: "{1,-2,3}"},
+		{name: "case_uints", array: Array{Slice: [2]uint8{1, 2}}, value: "{1,2}"},
+		{name: "case_strings", array: Array{Slice: []string{"a b", `it's "q"`, `c:\`, ""}}, value: `{"a b","it's \"q\"","c:\\",""}`},
+		{name: "case_floats", array: Array{Slice: []float64{0.1, 1e21, math.NaN(), math.Inf(1), math.Inf(-1)}}, value: "{0.1,1e+21,NaN,Infinity,-Infinity}"},
+		{name: "case_bools", array: Array{Slice: []bool{true, false}}, value: "{t,f}"},
+		{name: "case_bytes", array: Array{Slice: [][]byte{{1, 0xab}, nil}}, value: `{"\\x01ab",NULL}`},
+		{name: "case_nulls", array: Array{Slice: []interface{}{1, nil, &s, null}}, value: `{1,NULL,"b",NULL}`},
+		{name: "case_times", array: Array{Slice: []time.Time{time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)}}, value: `{"2020-01-02 03:04:05+00:00"}`},
+		{name: "case_nested", array: Array{Slice: [][]int{{1, 2}, {3, 4}}}, value: "{{1,2},{3,4}}"},
+		{name: "case_valuer", array: Array{Slice: []interface{}{Array{Slice: []int{1}}}}, value: `{"{1}"}`},
+		{name: "case_empty", array: Array{Slice: []int{}}, value: "{}"},
+		{name: "case_nil", array: Array{Slice: []int(nil)}, value: nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v, err := c.array.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, c.value, v)
+		})
+	}
+
+	_, err := Array{Slice: 1}.Value()
+	assert.True(t, errors.Is(err, ErrUnsupportedValue))
+
+	_, err = Array{Slice: []interface{}{map[string]int{}}}.Value()
+	assert.True(t, errors.Is(err, ErrUnsupportedValue))
+}
+
+func TestFormat_Array(t *testing.T) {
+	var ids = make([]int64, 5000)
+	for i := range ids {
+		ids[i] = int64(i)
+	}
+
+	q := Format("SELECT name FROM users WHERE id = ANY(%a) AND name <> ALL(%a) LIMIT %p", ids, Array{Slice: []string{"Tom"}}, 10)
+	assert.Equal(t, "SELECT name FROM users WHERE id = ANY($1) AND name <> ALL($2) LIMIT $3", q.String())
+	assert.Equal(t, []interface{}{Array{Slice: ids}, Array{Slice: []string{"Tom"}}, 10}, q.Params())
+
+	assert.Equal(t,
+		`/* qp: interpolated, not for execution */ SELECT id FROM users WHERE id = ANY('{1,2}')`,
+		Interpolate(Format("SELECT id FROM users WHERE id = ANY(%a)", []int{1, 2})),
+	)
+
+	q = Format("SELECT name FROM users WHERE id = ANY(%[1]a) OR parent_id = ANY(%[1]a) AND id IN (%[1]p)", []int{1, 2})
+	assert.Equal(t, "SELECT name FROM users WHERE id = ANY($1) OR parent_id = ANY($1) AND id IN ($2, $3)", q.String())
+	assert.Equal(t, []interface{}{Array{Slice: []int{1, 2}}, 1, 2}, q.Params())
+}
@@ -0,0 +1,60 @@
+package qp
+
+import "fmt"
+
+// Batch splits rows into statements, each under the parameter limit of the driver
+// The format has a single %s verb for rows joined by ", ", row is a template of one row
+// A nil driver means the default driver, no rows is ErrNoRows
+//		var row = qp.MustCompile("(%p, %p)")
+//		var batches, err = qp.Batch(qp.SqlserverDriver(), "INSERT INTO users (id, name) VALUES %s", row, rows)
+//		// every batch has at most 2098 parameters
+//		for _, b := range batches {
+//			if _, err = qp.Exec(ctx, tx, b); err != nil {
+//				return err
+//			}
+//		}
+func Batch(d Driver, format string, row *Template, rows [][]interface{}) ([]Formatter, error) {
+	var t = compile(format)
+	return batch(d, row, rows, func(values Formatter) Formatter {
+		var f = New().(*formatter)
+		f.bind(t, []interface{}{values})
+		return f
+	})
+}
+
+// batch splits rows into statements made by stmt
+func batch(d Driver, row *Template, rows [][]interface{}, stmt func(values Formatter) Formatter) ([]Formatter, error) {
+	var limit int
+	if len(rows) == 0 {
+		return nil, ErrNoRows
+	}
+	if d == nil {
+		d = defaultDriver()
+	}
+	if x, ok := d.(ParamLimiter); ok {
+		limit = x.MaxParams()
+	}
+
+	var (
+		batches []Formatter
+		values  *formatter
+		n       int
+	)
+	for i, r := range rows {
+		_, params, err := row.Bind(r...).Driver(d).Build()
+		if err != nil {
+			return nil, fmt.Errorf("%w (row %d)", err, i+1)
+		}
+		if limit > 0 && len(params) > limit {
+			return nil, fmt.Errorf("qp: %w (row %d)", ErrTooManyParams, i+1)
+		}
+		if values == nil || (limit > 0 && n+len(params) > limit) {
+			values = New().Jumper(", ").(*formatter)
+			batches = append(batches, stmt(values).Driver(d))
+			n = 0
+		}
+		values.bind(row, r)
+		n = n + len(params)
+	}
+	return batches, nil
+}
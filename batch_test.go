@@ -0,0 +1,77 @@
+package qp
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testLimitDriver is a Driver with a small parameter limit
+type testLimitDriver struct {
+	testDriver
+	limit int
+}
+
+func (d testLimitDriver) MaxParams() int {
+	return d.limit
+}
+
+func TestBatch(t *testing.T) {
+	var rows = make([][]interface{}, 1500)
+	for i := range rows {
+		rows[i] = []interface{}{i, "name", []int{i, i}}
+	}
+
+	batches, err := Batch(SqlserverDriver(), "INSERT INTO users (id, name, a, b) VALUES %s", MustCompile("(%p, %p, %p)"), rows)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 3)
+
+	for i, n := range []int{524, 524, 452} {
+		s, p, err := batches[i].Build()
+		assert.NoError(t, err)
+		assert.Len(t, p, n*4)
+		assert.Equal(t, n, strings.Count(s, "(@"))
+		assert.True(t, strings.HasPrefix(s, "INSERT INTO users (id, name, a, b) VALUES (@p1, @p2, @p3, @p4), (@p5"))
+	}
+
+	batches, err = Batch(nil, "INSERT INTO users (id, name, a, b) VALUES %s", MustCompile("(%p, %p, %p)"), rows)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 1)
+}
+
+func TestBatch_Small(t *testing.T) {
+	var rows = [][]interface{}{{1, "Tom"}, {2, "Huck"}, {3, "Becky"}}
+
+	batches, err := Batch(testLimitDriver{limit: 4}, "INSERT INTO users (id, name) VALUES %s", MustCompile("(%p, %p)"), rows)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 2)
+	assert.Equal(t, `INSERT INTO users (id, name) VALUES (?, ?), (?, ?)`, batches[0].String())
+	assert.Equal(t, []interface{}{1, "Tom", 2, "Huck"}, batches[0].Params())
+	assert.Equal(t, `INSERT INTO users (id, name) VALUES (?, ?)`, batches[1].String())
+	assert.Equal(t, []interface{}{3, "Becky"}, batches[1].Params())
+
+	_, err = Batch(testLimitDriver{limit: 1}, "INSERT INTO users (id, name) VALUES %s", MustCompile("(%p, %p)"), rows)
+	assert.True(t, errors.Is(err, ErrTooManyParams))
+	assert.EqualError(t, err, "qp: too many parameters (row 1)")
+
+	_, err = Batch(nil, "INSERT INTO users (id, name) VALUES %s", MustCompile("(%p, %p)"), [][]interface{}{{1, "Tom"}, {2}})
+	assert.True(t, errors.Is(err, ErrParamNotFound))
+	assert.EqualError(t, err, "qp: parameter not found (fragment 0, offset 5, verb %p) (row 2)")
+
+	_, err = Batch(nil, "INSERT INTO users (id, name) VALUES %s", MustCompile("(%p, %p)"), nil)
+	assert.True(t, errors.Is(err, ErrNoRows))
+}
+
+func TestInsert_Batches(t *testing.T) {
+	var users = []testUser{{Name: "Tom"}, {Name: "Huck"}, {Name: "Becky"}}
+
+	batches, err := Insert("users").Skip("tags", "created_at").Batches(testLimitDriver{limit: 2}, users)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 2)
+	assert.Equal(t, `INSERT INTO "users" ("name") VALUES (?), (?)`, batches[0].String())
+	assert.Equal(t, []interface{}{"Tom", "Huck"}, batches[0].Params())
+	assert.Equal(t, `INSERT INTO "users" ("name") VALUES (?)`, batches[1].String())
+	assert.Equal(t, []interface{}{"Becky"}, batches[1].Params())
+}
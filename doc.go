@@ -4,16 +4,23 @@
 // 			Format("name = %p", "Tom").
 // 			Format("age IN (%+p)", 18, 21, 30)
 //
-// 		var q = qp.Query("SELECT id FROM table WHERE %s LIMIT %p", b, 10)
+// 		var q = qp.Format("SELECT id FROM table WHERE %s LIMIT %p", b, 10)
 // 		_ = q.String() // SELECT id FROM table WHERE name = $1 AND age IN ($2, $3, $4) LIMIT $5
 // 		_ = q.Params() // ["Tom", 18, 21, 30, 10]
 //
+// 		rows, err := qp.Query(ctx, db, q) // db.QueryContext(ctx, q.String(), q.Params()...)
+//
 // The verbs:
 // 		%s		convert to string
 // 		%p		convert to one placeholder or slice placeholders
+// 		%i		convert to a quoted identifier or slice identifiers
+// 		%l		convert to an escaped sql literal or slice literals
+// 		%a		convert a slice to one postgresql array placeholder
 //
 // The modifiers:
 // 		+		capture all parameters
+// 		{name}		take a parameter by name, see FormatNamed
+// 		[n]		take the n-th parameter, counting from 1
 //
 // Examples:
 // 		qp.Format("name: %s", "Tom Sawyer").String() // name: Tom Sawyer
@@ -55,6 +62,12 @@
 // 		q := query.String() // UPDATE users SET (name, age) = ($1, $2) WHERE id = $3
 // 		p := query.Params() // ["Tom", 12, 1]
 //
+// 		query = qp.Update("users").
+// 			Set(map[string]interface{}{"name": "Tom", "visits": qp.Format("visits + %p", 1)}).
+// 			Where(qp.Format("id = %p", 7))
+// 		q = query.String() // UPDATE "users" SET "name" = $1, "visits" = visits + $2 WHERE id = $3
+// 		p = query.Params() // ["Tom", 1, 7]
+//
 // Insert:
 //		values := qp.
 //			Format("(%+p)", 1, "Tom", 12).
@@ -64,6 +77,134 @@
 // 		q := query.String() // INSERT INTO users (id, name, age) VALUES ($1, $2, $3), ($4, $5, $6)
 // 		p := query.Params() // [1, "Tom", 12, 2, "Huckleberry", 13]
 //
+// Drivers:
+//		qp.DefaultDriver("mysql")
+//		qp.Format("name = %p", "Tom").String() // name = ?
+//
+//		qp.Format("name = %p", "Tom").Driver(qp.SqliteNumberedDriver()).String() // name = ?1
+//
+//		// a custom driver with numbered placeholders, PlaceholderAt gets the number of parameters bound before x
+//		type dollarDriver struct{}
+//
+//		func (dollarDriver) Placeholder(x interface{}) string { return dollarDriver{}.PlaceholderAt(0, x) }
+//		func (dollarDriver) PlaceholderAt(n int, x interface{}) string { return "$" + strconv.Itoa(n+1) }
+//
+//		qp.RegisterDriver("dollar", func() qp.Driver { return dollarDriver{} })
+//		qp.Format("active = %p", true).Driver(qp.MysqlDriver()).Params() // [1]
+//
+// Identifiers:
+//		query := qp.Format("SELECT %i FROM %i ORDER BY %i", []string{"id", "name"}, "public.users", "created_at")
+//		q := query.String() // SELECT "id", "name" FROM "public"."users" ORDER BY "created_at"
+//
+// Literals:
+//		query := qp.Format("COMMENT ON TABLE %i IS %l", "users", "it's users")
+//		q := query.String() // COMMENT ON TABLE "users" IS 'it''s users'
+//
+// Strings:
+//		s := qp.DefaultStringifier()
+//		s.Time = func(t time.Time) string { return t.Format("2006-01-02") }
+//
+//		query := qp.WithStringifier(qp.Format("SELECT %s, %s, %s", 0.1234567, nil, time.Now()), s)
+//		q := query.String() // SELECT 0.1234567, NULL, 2020-01-24
+//
+// Slices:
+//		type IDs []uint32
+//
+//		query := qp.Format("SELECT name FROM users WHERE id IN (%p) AND data = %p", IDs{1, 2}, json.RawMessage(`{}`))
+//		q := query.String() // SELECT name FROM users WHERE id IN ($1, $2) AND data = $3
+//
+// Arrays:
+//		query := qp.Format("SELECT name FROM users WHERE id = ANY(%a)", []int{1, 2, 3})
+//		q := query.String() // SELECT name FROM users WHERE id = ANY($1)
+//		p := query.Params() // [{1,2,3}]
+//
+// Paging:
+//		query := qp.Format("SELECT id FROM users ORDER BY id %s", qp.Paging(10, 20))
+//		q := query.String() // SELECT id FROM users ORDER BY id LIMIT $1 OFFSET $2
+//		q = query.Driver(qp.SqlserverDriver()).String() // SELECT id FROM users ORDER BY id OFFSET @p1 ROWS FETCH NEXT @p2 ROWS ONLY
+//
+// Explicit indexes:
+//		query := qp.Format("SELECT id FROM users WHERE name = %[1]p OR nick = %[1]p LIMIT %p", "Tom", 10)
+//		q := query.String() // SELECT id FROM users WHERE name = $1 OR nick = $1 LIMIT $2
+//		p := query.Params() // ["Tom", 10]
+//
+// Named parameters:
+//		params := map[string]interface{}{"name": "Tom", "ids": []int{1, 2}}
+//		query := qp.FormatNamed("SELECT id FROM users WHERE (name = %{name}p OR nick = %{name}p) AND id IN (%{ids}p)", params)
+//		q := query.String() // SELECT id FROM users WHERE (name = $1 OR nick = $1) AND id IN ($2, $3)
+//		p := query.Params() // ["Tom", 1, 2]
+//
+//		query = qp.Format("SELECT name FROM users WHERE id = %p OR parent_id = %p", sql.Named("id", 5), sql.Named("id", 5))
+//		q = query.Driver(qp.SqlserverDriver()).String() // SELECT name FROM users WHERE id = @id OR parent_id = @id
+//		p = query.Params() // [{Name: "id", Value: 5}]
+//
+// Custom verbs:
+//		qp.RegisterVerb('j', func(w *qp.Writer, arg interface{}) error {
+//			w.WriteString("#> ")
+//			return w.Placeholder(qp.Array{Slice: arg})
+//		})
+//
+//		query := qp.Format("SELECT data %j FROM users", []string{"a", "b"})
+//		q := query.String() // SELECT data #> $1 FROM users
+//		p := query.Params() // [{a,b}]
+//
+// Templates:
+//		var byName = qp.MustCompile("SELECT id FROM users WHERE name = %p LIMIT %p")
+//
+//		query := byName.Bind("Tom", 10)
+//		q := query.String() // SELECT id FROM users WHERE name = $1 LIMIT $2
+//		p := query.Params() // ["Tom", 10]
+//
+// Logs:
+//		query := qp.Format("SELECT id FROM users WHERE name = %p AND password = %p", "Tom", "secret")
+//		log.Println(qp.Interpolate(query, qp.Redact(1))) // /* qp: interpolated, not for execution */ SELECT id FROM users WHERE name = 'Tom' AND password = '***'
+//
+// database/sql:
+//		_, err := qp.Exec(ctx, tx, qp.Format("DELETE FROM users WHERE id IN (%p)", ids))
+//
+//		var name string
+//		err = qp.QueryRow(ctx, db, qp.Format("SELECT name FROM users WHERE id = %p", 1)).Scan(&name)
+//
+// Errors:
+//		query, params, err := qp.Format("SELECT name FROM users WHERE id = %p AND age = %p", 1).Build()
+//		if err != nil {
+//			return err // qp: parameter not found (fragment 0, offset 47, verb %p)
+//		}
+//
+// Insert structs:
+//		type User struct {
+//			ID   int64  `db:"id,omitempty"`
+//			Name string `db:"name"`
+//			Age  int    `db:"age"`
+//		}
+//
+//		query := qp.Insert("users").Rows([]User{{Name: "Tom", Age: 12}, {Name: "Huckleberry", Age: 13}})
+//		q := query.String() // INSERT INTO "users" ("name", "age") VALUES ($1, $2), ($3, $4)
+//		p := query.Params() // ["Tom", 12, "Huckleberry", 13]
+//
+// Upsert:
+//		query := qp.Insert("users").OnConflict("id").DoUpdate("name").Rows(User{ID: 1, Name: "Tom", Age: 12})
+//		q := query.String() // INSERT INTO "users" ("id", "name", "age") VALUES ($1, $2, $3) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name"
+//		q = query.Driver(qp.MysqlDriver()).String() // INSERT INTO `users` (`id`, `name`, `age`) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE `name` = VALUES(`name`)
+//
+// Batches:
+//		batches, err := qp.Insert("users").Batches(qp.SqlserverDriver(), users) // 2098 parameters per statement
+//		if err != nil {
+//			return err
+//		}
+//		err = qp.ExecAll(ctx, tx, batches)
+//
+// Conditions:
+//		query := qp.And(qp.Format("a = %p", 1), qp.Or(qp.Format("b = %p", 2), qp.Format("c = %p", 3)))
+//		q := query.String() // a = $1 AND (b = $2 OR c = $3)
+//		p := query.Params() // [1, 2, 3]
+//		q = qp.Format("SELECT id FROM users WHERE %s LIMIT 10", qp.Or(qp.Format("a = %p", 1), qp.Format("b = %p", 2))).String()
+//		// SELECT id FROM users WHERE (a = $1 OR b = $2) LIMIT 10
+//
+//		q = qp.In("id", ids).String()         // "id" IN ($1, $2)
+//		q = qp.In("id", []int{}).String()     // 1=0
+//		q = qp.NotIn("id", []int{}).String()  // 1=1
+//
 // Filter:
 //		type (
 //			CarFilter struct {
@@ -89,8 +230,8 @@
 //			}
 //		)
 //
-//		func (r *CarRepository) GetByFilter(filter CarFilter) (_ []*Car, err error) {
-//			var builder = qp.Format("1=1")
+//		func (r *CarRepository) GetByFilter(ctx context.Context, filter CarFilter) (_ []*Car, err error) {
+//			var builder = qp.And()
 //
 //			if len(filter.Mark) > 0 {
 //				builder.Format("mark = %p", filter.Mark)
@@ -121,7 +262,7 @@
 //			`, builder, filter.Limit, filter.Offset)
 //
 //			var rows *sql.Rows
-//			if rows, err = r.db.Query(query.String(), query.Params()...); err != nil {
+//			if rows, err = qp.Query(ctx, r.db, query); err != nil {
 //				return nil, err
 //			}
 //			defer rows.Close()
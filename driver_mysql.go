@@ -1,12 +1,36 @@
 package qp
 
 import (
-	"unsafe"
+	"encoding/hex"
+	"time"
 )
 
-type mysqlDriver struct{}
+type mysqlDriver struct {
+	intBools bool
+}
 
-var _ Driver = (*mysqlDriver)(nil)
+var (
+	_ Driver         = (*mysqlDriver)(nil)
+	_ Quoter         = (*mysqlDriver)(nil)
+	_ LiteralEncoder = (*mysqlDriver)(nil)
+	_ ParamLimiter   = (*mysqlDriver)(nil)
+	_ ParamConverter = (*mysqlDriver)(nil)
+	_ Upserter       = (*mysqlDriver)(nil)
+)
+
+// mysqlLiteral encodes literals for mysql with backslash escapes
+var mysqlLiteral = literal{
+	string: func(s string) string {
+		return "'" + escape(s) + "'"
+	},
+	bytes: func(b []byte) string {
+		return "X'" + hex.EncodeToString(b) + "'"
+	},
+	time: func(t time.Time) string {
+		return "'" + t.Format("2006-01-02 15:04:05.999999") + "'"
+	},
+	bools: [2]string{"FALSE", "TRUE"},
+}
 
 func init() {
 	RegisterDriver("mysql", MysqlDriver)
@@ -17,29 +41,53 @@ func MysqlDriver() Driver {
 	return &mysqlDriver{}
 }
 
-// Placeholder returns n count placeholders
+// MysqlIntBoolDriver returns a specific Driver for mysql which binds booleans as 1 and 0,
+// it is for setups whose connector can't send booleans
+func MysqlIntBoolDriver() Driver {
+	return &mysqlDriver{intBools: true}
+}
+
+// Placeholder returns count placeholders
 func (d *mysqlDriver) Placeholder(x interface{}) string {
-	var n int
-	switch n = count(x); n {
-	case 0:
-		return ""
-	case 1:
-		return "?"
-	}
+	return repeated("?", x)
+}
+
+// appendPlaceholder appends count placeholders to b
+func (d *mysqlDriver) appendPlaceholder(b []byte, _ int, x interface{}) []byte {
+	return appendRepeated(b, "?", x)
+}
 
-	var (
-		p = ", ?"
-		b = make([]byte, len(p)*n)
-		w = copy(b, p)
-	)
+// QuoteIdent quotes an identifier with backticks
+func (d *mysqlDriver) QuoteIdent(name string) string {
+	return quote(name, '`', '`')
+}
 
-	for w < len(b) {
-		copy(b[w:], b[:w])
-		w *= 2
-	}
-	if len(b) >= len(p) {
-		b = b[2:]
+// EncodeLiteral returns x as a sql literal
+func (d *mysqlDriver) EncodeLiteral(x interface{}) (string, error) {
+	return mysqlLiteral.encode(x)
+}
+
+// MaxParams returns the maximum number of parameters in a statement,
+// mysql uses a 16-bit number of placeholders
+func (d *mysqlDriver) MaxParams() int {
+	return 65535
+}
+
+// Upsert returns INSERT ... ON DUPLICATE KEY UPDATE or INSERT IGNORE,
+// mysql resolves conflicts on any unique key, so conflict columns are not used
+func (d *mysqlDriver) Upsert(u *Upsert) Formatter {
+	if len(u.Update) == 0 {
+		return Format("INSERT IGNORE INTO %i (%i) VALUES %s", u.Table, u.Columns, u.Values)
 	}
+	return Format("INSERT INTO %i (%i) VALUES %s ON DUPLICATE KEY UPDATE %s",
+		u.Table, u.Columns, u.Values, assignments("%i = VALUES(%i)", ", ", u.Update))
+}
 
-	return *(*string)(unsafe.Pointer(&b))
+// ConvertParam converts booleans to 1 and 0 if the driver binds booleans as ints,
+// mysql stores them as TINYINT(1)
+func (d *mysqlDriver) ConvertParam(x interface{}) (interface{}, error) {
+	if b, ok := x.(bool); ok && d.intBools {
+		return int64(btoi(b)), nil
+	}
+	return x, nil
 }
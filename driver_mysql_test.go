@@ -31,6 +31,11 @@ func TestMySQL_Placeholder(t *testing.T) {
 	assert.Equal(t, `?, ?, ?, ?, ?, ?`, res)
 }
 
+func TestMySQL_QuoteIdent(t *testing.T) {
+	res := MysqlDriver().(Quoter).QuoteIdent("my`table")
+	assert.Equal(t, "`my``table`", res)
+}
+
 func BenchmarkMySQL_Placeholder(b *testing.B) {
 	var d = MysqlDriver()
 	var s = []int64{1, 2, 3}
@@ -38,3 +43,12 @@ func BenchmarkMySQL_Placeholder(b *testing.B) {
 		_ = d.Placeholder(s)
 	}
 }
+
+func TestMySQL_ConvertParam(t *testing.T) {
+	q := Format("UPDATE users SET active = %p, deleted = %p WHERE id = %p", true, false, uint64(1)).Driver(MysqlDriver())
+	assert.Equal(t, []interface{}{true, false, uint64(1)}, q.Params())
+
+	q.Driver(MysqlIntBoolDriver())
+	assert.Equal(t, "UPDATE users SET active = ?, deleted = ? WHERE id = ?", q.String())
+	assert.Equal(t, []interface{}{int64(1), int64(0), uint64(1)}, q.Params())
+}
@@ -0,0 +1,146 @@
+package qp
+
+import (
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+type oracleDriver struct{}
+
+var (
+	_ Driver            = (*oracleDriver)(nil)
+	_ Positional        = (*oracleDriver)(nil)
+	_ Pager             = (*oracleDriver)(nil)
+	_ Quoter            = (*oracleDriver)(nil)
+	_ LiteralEncoder    = (*oracleDriver)(nil)
+	_ ParamLimiter      = (*oracleDriver)(nil)
+	_ Upserter          = (*oracleDriver)(nil)
+	_ NamedPlaceholder  = (*oracleDriver)(nil)
+	_ StringifierDriver = (*oracleDriver)(nil)
+)
+
+// oracleLiteral encodes literals for oracle
+var oracleLiteral = literal{
+	string: func(s string) string {
+		return quote(s, '\'', '\'')
+	},
+	bytes: func(b []byte) string {
+		return "HEXTORAW('" + hex.EncodeToString(b) + "')"
+	},
+	time: func(t time.Time) string {
+		return "TIMESTAMP '" + t.Format("2006-01-02 15:04:05.999999999 -07:00") + "'"
+	},
+	bools: [2]string{"0", "1"},
+}
+
+func init() {
+	RegisterDriver("oracle", OracleDriver)
+}
+
+// OracleDriver returns a specific Driver for oracle
+func OracleDriver() Driver {
+	return &oracleDriver{}
+}
+
+// Placeholder returns string of bind variables numbered from 1
+func (d *oracleDriver) Placeholder(x interface{}) string {
+	return d.PlaceholderAt(0, x)
+}
+
+// PlaceholderAt returns string of bind variables numbered from n+1
+func (d *oracleDriver) PlaceholderAt(n int, x interface{}) string {
+	return numbered(":", n, x)
+}
+
+// appendPlaceholder appends placeholders numbered from n+1 to b
+func (d *oracleDriver) appendPlaceholder(b []byte, n int, x interface{}) []byte {
+	return appendNumbered(b, ":", n, count(x))
+}
+
+// Paging returns "OFFSET n ROWS FETCH NEXT m ROWS ONLY" clause,
+// it is supported since oracle 12c
+func (d *oracleDriver) Paging(limit, offset interface{}) Formatter {
+	return Format("OFFSET %p ROWS FETCH NEXT %p ROWS ONLY", offset, limit)
+}
+
+// QuoteIdent quotes an identifier with double quotes,
+// a lower case name which needs no quotes is upper cased, as oracle stores unquoted names in upper case
+//		users => "USERS"
+//		userName => "userName"
+func (d *oracleDriver) QuoteIdent(name string) string {
+	if plainLower(name) {
+		name = strings.ToUpper(name)
+	}
+	return quote(name, '"', '"')
+}
+
+// plainLower reports whether name is a lower case identifier which is valid without quotes
+func plainLower(name string) bool {
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		return false
+	}
+	for i := 1; i < len(name); i++ {
+		var c = name[i]
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '_' || c == '$' || c == '#') {
+			return false
+		}
+	}
+	return true
+}
+
+// EncodeLiteral returns x as a sql literal
+func (d *oracleDriver) EncodeLiteral(x interface{}) (string, error) {
+	return oracleLiteral.encode(x)
+}
+
+// MaxParams returns the maximum number of parameters in a statement,
+// oracle allows at most 65535 bind variables
+func (d *oracleDriver) MaxParams() int {
+	return 65535
+}
+
+// Upsert returns a MERGE statement with rows selected from dual,
+// values must be rows of Insert
+func (d *oracleDriver) Upsert(u *Upsert) Formatter {
+	var values, ok = u.Values.(*formatter)
+	if !ok {
+		return Format("%s", &failed{err: unsupported(u.Values)})
+	}
+	if len(u.Conflict) == 0 {
+		return Format("%s", &failed{err: ErrNoColumns})
+	}
+
+	var (
+		row    = compile("SELECT " + strings.TrimSuffix(strings.Repeat("%p %i, ", len(u.Columns)), ", ") + " FROM dual")
+		source = New().Jumper(" UNION ALL ").(*formatter)
+	)
+	for _, params := range values.params {
+		if len(params) != len(u.Columns) {
+			return Format("%s", &failed{err: unsupported(u.Values)})
+		}
+		var args = make([]interface{}, 0, 2*len(params))
+		for i, x := range params {
+			args = append(args, x, u.Columns[i])
+		}
+		source.bind(row, args)
+	}
+
+	var f = Format("MERGE INTO %i target USING (%s) source ON (%s)",
+		u.Table, source, assignments("target.%i = source.%i", " AND ", u.Conflict))
+	if len(u.Update) > 0 {
+		f = Format("%s WHEN MATCHED THEN UPDATE SET %s", f, assignments("target.%i = source.%i", ", ", u.Update))
+	}
+	return Format("%s WHEN NOT MATCHED THEN INSERT (%i) VALUES (%s)",
+		f, u.Columns, assignments("source.%i", ", ", u.Columns))
+}
+
+// NamedPlaceholder returns a bind variable of a sql.NamedArg, :name
+func (d *oracleDriver) NamedPlaceholder(name string) string {
+	return ":" + name
+}
+
+// Stringifier returns a Stringifier of the %s verb
+func (d *oracleDriver) Stringifier() *Stringifier {
+	return bitStringifier
+}
@@ -0,0 +1,79 @@
+package qp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOracle_Placeholder(t *testing.T) {
+	var res string
+
+	res = OracleDriver().Placeholder(1)
+	assert.Equal(t, `:1`, res)
+
+	res = OracleDriver().Placeholder([]byte{'a', 'b', 'c'})
+	assert.Equal(t, `:1`, res)
+
+	res = OracleDriver().Placeholder([]int{})
+	assert.Equal(t, ``, res)
+
+	res = OracleDriver().Placeholder([]int{1, 2})
+	assert.Equal(t, `:1, :2`, res)
+
+	res = OracleDriver().Placeholder([]int64{1, 2, 3})
+	assert.Equal(t, `:1, :2, :3`, res)
+
+	res = OracleDriver().Placeholder([]string{"Tom", "Sawyer"})
+	assert.Equal(t, `:1, :2`, res)
+
+	res = OracleDriver().Placeholder([]interface{}{1, "Tom", true})
+	assert.Equal(t, `:1, :2, :3`, res)
+
+	res = OracleDriver().Placeholder([]interface{}{[]interface{}{1, "Tom", true, []byte{'a', 'b', 'c'}}})
+	assert.Equal(t, `:1, :2, :3, :4`, res)
+
+	res = OracleDriver().Placeholder([]interface{}{[]int{1, 2}, []int64{3, 4, 5}, 6})
+	assert.Equal(t, `:1, :2, :3, :4, :5, :6`, res)
+
+	res = OracleDriver().(Positional).PlaceholderAt(9, []interface{}{[]int{1, 2}, []interface{}{3, []string{"a"}}})
+	assert.Equal(t, `:10, :11, :12, :13`, res)
+}
+
+func TestOracle_Format(t *testing.T) {
+	b := Format("name = %p", "Tom")
+	q := Format(
+		"SELECT id FROM table WHERE id IN (%p) AND %s ORDER BY id %s",
+		[]interface{}{1, []int{2, 3}}, b, Paging(10, 0),
+	).Driver(OracleDriver())
+	assert.Equal(t,
+		`SELECT id FROM table WHERE id IN (:1, :2, :3) AND name = :4 ORDER BY id OFFSET :5 ROWS FETCH NEXT :6 ROWS ONLY`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{1, 2, 3, "Tom", 0, 10},
+		q.Params(),
+	)
+}
+
+func TestOracle_QuoteIdent(t *testing.T) {
+	res := OracleDriver().(Quoter).QuoteIdent(`my"table`)
+	assert.Equal(t, `"my""table"`, res)
+
+	res = OracleDriver().(Quoter).QuoteIdent(`users_2$`)
+	assert.Equal(t, `"USERS_2$"`, res)
+
+	res = OracleDriver().(Quoter).QuoteIdent(`userName`)
+	assert.Equal(t, `"userName"`, res)
+
+	q := Format("SELECT %i FROM %i", []string{"id", "u.*"}, "app.users").Driver(OracleDriver())
+	assert.Equal(t, `SELECT "ID", "U".* FROM "APP"."USERS"`, q.String())
+}
+
+func BenchmarkOracle_Placeholder(b *testing.B) {
+	var d = OracleDriver()
+	var s = []int64{1, 2, 3}
+	for i := 0; i < b.N; i++ {
+		_ = d.Placeholder(s)
+	}
+}
@@ -1,15 +1,37 @@
 package qp
 
 import (
+	"encoding/hex"
+	"math"
 	"strconv"
-	"unsafe"
+	"time"
 )
 
-type pgsqlDriver struct {
-	placeholders int
-}
+type pgsqlDriver struct{}
 
-var _ Driver = (*pgsqlDriver)(nil)
+var (
+	_ Driver         = (*pgsqlDriver)(nil)
+	_ Positional     = (*pgsqlDriver)(nil)
+	_ Numbered       = (*pgsqlDriver)(nil)
+	_ Quoter         = (*pgsqlDriver)(nil)
+	_ LiteralEncoder = (*pgsqlDriver)(nil)
+	_ ParamLimiter   = (*pgsqlDriver)(nil)
+	_ ParamConverter = (*pgsqlDriver)(nil)
+)
+
+// pgsqlLiteral encodes literals for postgresql with standard_conforming_strings on
+var pgsqlLiteral = literal{
+	string: func(s string) string {
+		return quote(s, '\'', '\'')
+	},
+	bytes: func(b []byte) string {
+		return "'\\x" + hex.EncodeToString(b) + "'::bytea"
+	},
+	time: func(t time.Time) string {
+		return "'" + t.Format("2006-01-02 15:04:05.999999-07:00") + "'::timestamptz"
+	},
+	bools: [2]string{"FALSE", "TRUE"},
+}
 
 func init() {
 	RegisterDriver("postgres", PgsqlDriver)
@@ -20,37 +42,58 @@ func PgsqlDriver() Driver {
 	return &pgsqlDriver{}
 }
 
-// Placeholder returns n count placeholders
-func (d *pgsqlDriver) placeholder() int {
-	d.placeholders++
-	return d.placeholders
+// Placeholder returns string of placeholders numbered from 1
+func (d *pgsqlDriver) Placeholder(x interface{}) string {
+	return d.PlaceholderAt(0, x)
 }
 
-// Placeholder returns string of placeholders
-func (d *pgsqlDriver) Placeholder(x interface{}) string {
-	var n int
-	switch n = count(x); n {
-	case 0:
-		return ""
-	case 1:
-		return "$" + strconv.Itoa(d.placeholder())
-	}
+// PlaceholderAt returns string of placeholders numbered from n+1
+func (d *pgsqlDriver) PlaceholderAt(n int, x interface{}) string {
+	return numbered("$", n, x)
+}
 
-	var (
-		sep = ", "
-		cap = len(sep)*(n-1) + n
-	)
-	for i := 1; i <= n; i++ {
-		cap += intWeight(d.placeholders + i)
-	}
+// appendPlaceholder appends placeholders numbered from n+1 to b
+func (d *pgsqlDriver) appendPlaceholder(b []byte, n int, x interface{}) []byte {
+	return appendNumbered(b, "$", n, count(x))
+}
 
-	var b = make([]byte, 0, cap)
-	b = append(b, '$')
-	b = strconv.AppendInt(b, int64(d.placeholder()), 10)
-	for i := 1; i < n; i++ {
-		b = append(b, ',', ' ', '$')
-		b = strconv.AppendInt(b, int64(d.placeholder()), 10)
+// Numbered reports that placeholders are numbered
+func (d *pgsqlDriver) Numbered() bool {
+	return true
+}
+
+// QuoteIdent quotes an identifier with double quotes
+func (d *pgsqlDriver) QuoteIdent(name string) string {
+	return quote(name, '"', '"')
+}
+
+// EncodeLiteral returns x as a sql literal
+func (d *pgsqlDriver) EncodeLiteral(x interface{}) (string, error) {
+	return pgsqlLiteral.encode(x)
+}
+
+// MaxParams returns the maximum number of parameters in a statement,
+// postgresql uses a 16-bit number of parameters
+func (d *pgsqlDriver) MaxParams() int {
+	return 65535
+}
+
+// ConvertParam converts unsigned integers to int64,
+// a value over the int64 range is a decimal string, postgresql casts it to numeric
+func (d *pgsqlDriver) ConvertParam(x interface{}) (interface{}, error) {
+	switch v := x.(type) {
+	case uint:
+		return unsigned(uint64(v)), nil
+	case uint64:
+		return unsigned(v), nil
 	}
+	return x, nil
+}
 
-	return *(*string)(unsafe.Pointer(&b))
+// unsigned returns x as int64 or as a decimal string if x is over the int64 range
+func unsigned(x uint64) interface{} {
+	if x > math.MaxInt64 {
+		return strconv.FormatUint(x, 10)
+	}
+	return int64(x)
 }
@@ -1,6 +1,8 @@
 package qp
 
 import (
+	"database/sql"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -35,6 +37,19 @@ func TestPgSQL_Placeholder(t *testing.T) {
 
 	res = PgsqlDriver().Placeholder([]interface{}{[]int{1, 2}, []int64{3, 4, 5}, 6})
 	assert.Equal(t, `$1, $2, $3, $4, $5, $6`, res)
+
+	res = PgsqlDriver().(Positional).PlaceholderAt(9, []int{1, 2})
+	assert.Equal(t, `$10, $11`, res)
+}
+
+func TestPgSQL_QuoteIdent(t *testing.T) {
+	res := PgsqlDriver().(Quoter).QuoteIdent(`my"table`)
+	assert.Equal(t, `"my""table"`, res)
+}
+
+func TestPgSQL_ConvertParam(t *testing.T) {
+	q := Format("SELECT id FROM users WHERE id IN (%p) AND age = %p", []uint64{1, math.MaxUint64}, sql.Named("age", uint(12)))
+	assert.Equal(t, []interface{}{int64(1), "18446744073709551615", int64(12)}, q.Params())
 }
 
 func BenchmarkPgSQL_Placeholder(b *testing.B) {
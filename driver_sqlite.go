@@ -0,0 +1,92 @@
+package qp
+
+import (
+	"encoding/hex"
+	"time"
+)
+
+type sqliteDriver struct {
+	numbered bool
+}
+
+var (
+	_ Driver         = (*sqliteDriver)(nil)
+	_ Positional     = (*sqliteDriver)(nil)
+	_ Numbered       = (*sqliteDriver)(nil)
+	_ Quoter         = (*sqliteDriver)(nil)
+	_ LiteralEncoder = (*sqliteDriver)(nil)
+	_ ParamLimiter   = (*sqliteDriver)(nil)
+)
+
+// sqliteLiteral encodes literals for sqlite, times are stored as text
+var sqliteLiteral = literal{
+	string: func(s string) string {
+		return quote(s, '\'', '\'')
+	},
+	bytes: func(b []byte) string {
+		return "X'" + hex.EncodeToString(b) + "'"
+	},
+	time: func(t time.Time) string {
+		return "'" + t.Format("2006-01-02 15:04:05.999999999-07:00") + "'"
+	},
+	bools: [2]string{"0", "1"},
+}
+
+func init() {
+	RegisterDriver("sqlite", SqliteDriver)
+}
+
+// SqliteDriver returns a specific Driver for sqlite with "?" placeholders
+func SqliteDriver() Driver {
+	return &sqliteDriver{}
+}
+
+// SqliteNumberedDriver returns a specific Driver for sqlite with "?NNN" placeholders
+func SqliteNumberedDriver() Driver {
+	return &sqliteDriver{numbered: true}
+}
+
+// Placeholder returns string of placeholders,
+// numbered from 1 if the driver is numbered
+func (d *sqliteDriver) Placeholder(x interface{}) string {
+	return d.PlaceholderAt(0, x)
+}
+
+// PlaceholderAt returns string of placeholders,
+// numbered from n+1 if the driver is numbered
+func (d *sqliteDriver) PlaceholderAt(n int, x interface{}) string {
+	if d.numbered {
+		return numbered("?", n, x)
+	}
+	return repeated("?", x)
+}
+
+// appendPlaceholder appends placeholders to b,
+// numbered from n+1 if the driver is numbered
+func (d *sqliteDriver) appendPlaceholder(b []byte, n int, x interface{}) []byte {
+	if d.numbered {
+		return appendNumbered(b, "?", n, count(x))
+	}
+	return appendRepeated(b, "?", x)
+}
+
+// Numbered reports whether placeholders are numbered
+func (d *sqliteDriver) Numbered() bool {
+	return d.numbered
+}
+
+// QuoteIdent quotes an identifier with double quotes
+func (d *sqliteDriver) QuoteIdent(name string) string {
+	return quote(name, '"', '"')
+}
+
+// EncodeLiteral returns x as a sql literal
+func (d *sqliteDriver) EncodeLiteral(x interface{}) (string, error) {
+	return sqliteLiteral.encode(x)
+}
+
+// MaxParams returns the maximum number of parameters in a statement,
+// SQLITE_MAX_VARIABLE_NUMBER of sqlite since 3.32.0
+func (d *sqliteDriver) MaxParams() int {
+	return 32766
+}
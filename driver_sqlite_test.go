@@ -0,0 +1,84 @@
+package qp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLite_Placeholder(t *testing.T) {
+	var res string
+
+	res = SqliteDriver().Placeholder(1)
+	assert.Equal(t, `?`, res)
+
+	res = SqliteDriver().Placeholder([]int{})
+	assert.Equal(t, ``, res)
+
+	res = SqliteDriver().Placeholder([]int{1, 2})
+	assert.Equal(t, `?, ?`, res)
+
+	res = SqliteDriver().Placeholder([]int64{1, 2, 3})
+	assert.Equal(t, `?, ?, ?`, res)
+
+	res = SqliteDriver().Placeholder([]string{"Tom", "Sawyer"})
+	assert.Equal(t, `?, ?`, res)
+
+	res = SqliteDriver().Placeholder([]interface{}{1, "Tom", true})
+	assert.Equal(t, `?, ?, ?`, res)
+
+	res = SqliteDriver().Placeholder([]interface{}{[]int{1, 2}, []int{3, 4, 5}, 6})
+	assert.Equal(t, `?, ?, ?, ?, ?, ?`, res)
+}
+
+func TestSQLite_NumberedPlaceholder(t *testing.T) {
+	var res string
+
+	res = SqliteNumberedDriver().Placeholder(1)
+	assert.Equal(t, `?1`, res)
+
+	res = SqliteNumberedDriver().Placeholder([]int{})
+	assert.Equal(t, ``, res)
+
+	res = SqliteNumberedDriver().Placeholder([]int{1, 2})
+	assert.Equal(t, `?1, ?2`, res)
+
+	res = SqliteNumberedDriver().Placeholder([]interface{}{[]int{1, 2}, []int{3, 4, 5}, 6})
+	assert.Equal(t, `?1, ?2, ?3, ?4, ?5, ?6`, res)
+
+	res = SqliteNumberedDriver().(Positional).PlaceholderAt(9, []int{1, 2})
+	assert.Equal(t, `?10, ?11`, res)
+}
+
+func TestSQLite_Format(t *testing.T) {
+	b := Format("name = %p", "Tom")
+	q := Format(
+		"SELECT id FROM table WHERE id IN (%p) AND %s LIMIT %p",
+		[]int{1, 2}, b, 10,
+	)
+	assert.Equal(t,
+		`SELECT id FROM table WHERE id IN (?, ?) AND name = ? LIMIT ?`,
+		q.Driver(SqliteDriver()).String(),
+	)
+	assert.Equal(t,
+		`SELECT id FROM table WHERE id IN (?1, ?2) AND name = ?3 LIMIT ?4`,
+		q.Driver(SqliteNumberedDriver()).String(),
+	)
+	assert.Equal(t,
+		[]interface{}{1, 2, "Tom", 10},
+		q.Params(),
+	)
+}
+
+func TestSQLite_QuoteIdent(t *testing.T) {
+	res := SqliteDriver().(Quoter).QuoteIdent(`my"table`)
+	assert.Equal(t, `"my""table"`, res)
+}
+
+func BenchmarkSQLite_Placeholder(b *testing.B) {
+	var d = SqliteDriver()
+	var s = []int64{1, 2, 3}
+	for i := 0; i < b.N; i++ {
+		_ = d.Placeholder(s)
+	}
+}
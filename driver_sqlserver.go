@@ -0,0 +1,110 @@
+package qp
+
+import (
+	"encoding/hex"
+	"time"
+)
+
+type sqlserverDriver struct{}
+
+var (
+	_ Driver            = (*sqlserverDriver)(nil)
+	_ Positional        = (*sqlserverDriver)(nil)
+	_ Pager             = (*sqlserverDriver)(nil)
+	_ Numbered          = (*sqlserverDriver)(nil)
+	_ Quoter            = (*sqlserverDriver)(nil)
+	_ LiteralEncoder    = (*sqlserverDriver)(nil)
+	_ ParamLimiter      = (*sqlserverDriver)(nil)
+	_ Upserter          = (*sqlserverDriver)(nil)
+	_ NamedPlaceholder  = (*sqlserverDriver)(nil)
+	_ StringifierDriver = (*sqlserverDriver)(nil)
+)
+
+// sqlserverLiteral encodes literals for sql server, strings are unicode
+var sqlserverLiteral = literal{
+	string: func(s string) string {
+		return "N" + quote(s, '\'', '\'')
+	},
+	bytes: func(b []byte) string {
+		return "0x" + hex.EncodeToString(b)
+	},
+	time: func(t time.Time) string {
+		return "'" + t.Format("2006-01-02T15:04:05.9999999-07:00") + "'"
+	},
+	bools: [2]string{"0", "1"},
+}
+
+func init() {
+	RegisterDriver("sqlserver", SqlserverDriver)
+}
+
+// SqlserverDriver returns a specific Driver for sql server
+func SqlserverDriver() Driver {
+	return &sqlserverDriver{}
+}
+
+// Placeholder returns string of placeholders numbered from 1
+func (d *sqlserverDriver) Placeholder(x interface{}) string {
+	return d.PlaceholderAt(0, x)
+}
+
+// PlaceholderAt returns string of placeholders numbered from n+1
+func (d *sqlserverDriver) PlaceholderAt(n int, x interface{}) string {
+	return numbered("@p", n, x)
+}
+
+// appendPlaceholder appends placeholders numbered from n+1 to b
+func (d *sqlserverDriver) appendPlaceholder(b []byte, n int, x interface{}) []byte {
+	return appendNumbered(b, "@p", n, count(x))
+}
+
+// Paging returns "OFFSET n ROWS FETCH NEXT m ROWS ONLY" clause,
+// sql server requires ORDER BY before it
+func (d *sqlserverDriver) Paging(limit, offset interface{}) Formatter {
+	return Format("OFFSET %p ROWS FETCH NEXT %p ROWS ONLY", offset, limit)
+}
+
+// Numbered reports that placeholders are numbered
+func (d *sqlserverDriver) Numbered() bool {
+	return true
+}
+
+// QuoteIdent quotes an identifier with brackets
+func (d *sqlserverDriver) QuoteIdent(name string) string {
+	return quote(name, '[', ']')
+}
+
+// EncodeLiteral returns x as a sql literal
+func (d *sqlserverDriver) EncodeLiteral(x interface{}) (string, error) {
+	return sqlserverLiteral.encode(x)
+}
+
+// MaxParams returns the maximum number of parameters in a statement,
+// sql server allows at most 2100 parameters in a request and sp_executesql takes 2 of them
+func (d *sqlserverDriver) MaxParams() int {
+	return 2098
+}
+
+// Upsert returns a MERGE statement with rows of a table value constructor
+func (d *sqlserverDriver) Upsert(u *Upsert) Formatter {
+	if len(u.Conflict) == 0 {
+		return Format("%s", &failed{err: ErrNoColumns})
+	}
+	var f = Format("MERGE INTO %i WITH (HOLDLOCK) AS target USING (VALUES %s) AS source (%i) ON %s",
+		u.Table, u.Values, u.Columns, assignments("target.%i = source.%i", " AND ", u.Conflict))
+	if len(u.Update) > 0 {
+		f = Format("%s WHEN MATCHED THEN UPDATE SET %s", f, assignments("target.%i = source.%i", ", ", u.Update))
+	}
+	return Format("%s WHEN NOT MATCHED THEN INSERT (%i) VALUES (%s);",
+		f, u.Columns, assignments("source.%i", ", ", u.Columns))
+}
+
+// NamedPlaceholder returns a placeholder of a sql.NamedArg, @name
+func (d *sqlserverDriver) NamedPlaceholder(name string) string {
+	return "@" + name
+}
+
+// Stringifier returns a Stringifier of the %s verb
+func (d *sqlserverDriver) Stringifier() *Stringifier {
+	return bitStringifier
+}
@@ -0,0 +1,63 @@
+package qp
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLServer_Placeholder(t *testing.T) {
+	var res string
+
+	res = SqlserverDriver().Placeholder(1)
+	assert.Equal(t, `@p1`, res)
+
+	res = SqlserverDriver().Placeholder([]byte{'a', 'b', 'c'})
+	assert.Equal(t, `@p1`, res)
+
+	res = SqlserverDriver().Placeholder([]int{})
+	assert.Equal(t, ``, res)
+
+	res = SqlserverDriver().Placeholder([]int{1, 2})
+	assert.Equal(t, `@p1, @p2`, res)
+
+	res = SqlserverDriver().Placeholder([]int64{1, 2, 3})
+	assert.Equal(t, `@p1, @p2, @p3`, res)
+
+	res = SqlserverDriver().Placeholder([]string{"Tom", "Sawyer"})
+	assert.Equal(t, `@p1, @p2`, res)
+
+	res = SqlserverDriver().Placeholder([]interface{}{[]int{1, 2}, []int64{3, 4, 5}, 6})
+	assert.Equal(t, `@p1, @p2, @p3, @p4, @p5, @p6`, res)
+
+	res = SqlserverDriver().(Positional).PlaceholderAt(9, []int{1, 2})
+	assert.Equal(t, `@p10, @p11`, res)
+}
+
+func TestSQLServer_QuoteIdent(t *testing.T) {
+	res := SqlserverDriver().(Quoter).QuoteIdent(`my]table`)
+	assert.Equal(t, `[my]]table]`, res)
+}
+
+func TestSQLServer_NamedArg(t *testing.T) {
+	q := Format("SELECT id FROM users WHERE id = %p AND name = %p OR parent_id = %p", sql.Named("id", 5), "Tom", sql.Named("id", 5)).
+		Driver(SqlserverDriver())
+	assert.Equal(t, `SELECT id FROM users WHERE id = @id AND name = @p2 OR parent_id = @id`, q.String())
+	assert.Equal(t, []interface{}{sql.Named("id", 5), "Tom"}, q.Params())
+
+	_, _, err := Format("SELECT id FROM users WHERE id = %p OR parent_id = %p", sql.Named("id", 1), sql.Named("id", 2)).
+		Driver(SqlserverDriver()).
+		Build()
+	assert.True(t, errors.Is(err, ErrNamedArgConflict))
+	assert.EqualError(t, err, "qp: named parameter with different values 'id' (fragment 0, offset 50, verb %p)")
+}
+
+func BenchmarkSQLServer_Placeholder(b *testing.B) {
+	var d = SqlserverDriver()
+	var s = []int64{1, 2, 3}
+	for i := 0; i < b.N; i++ {
+		_ = d.Placeholder(s)
+	}
+}
@@ -0,0 +1,67 @@
+package qp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var (
+	// ErrParamNotFound is returned when a verb has no matching parameter
+	ErrParamNotFound = errors.New("parameter not found")
+
+	// ErrTooManyParams is returned when a Template is bound with extra parameters
+	ErrTooManyParams = errors.New("too many parameters")
+
+	// ErrUnsupportedValue is returned when a value has no sql literal
+	ErrUnsupportedValue = errors.New("unsupported value")
+
+	// ErrNoRows is returned when there are no rows to insert
+	ErrNoRows = errors.New("no rows")
+
+	// ErrNoColumns is returned when there are no columns to update
+	ErrNoColumns = errors.New("no columns")
+
+	// ErrDriverNotFound is returned when a driver is not registered
+	ErrDriverNotFound = errors.New("driver not found")
+
+	// ErrNamedArgConflict is returned when a sql.NamedArg name is bound with different values
+	ErrNamedArgConflict = errors.New("named parameter with different values")
+
+	// ErrForeignFormatter is returned when a nested Formatter which is not made by the package has parameters,
+	// its placeholders can't be numbered and rendered with the driver of the query
+	ErrForeignFormatter = errors.New("foreign formatter with parameters")
+)
+
+// FormatError describes a problem with a verb of a format fragment
+type FormatError struct {
+	Fragment int    // index of the fragment passed to Format
+	Offset   int    // byte offset of the verb in the fragment
+	Verb     byte   // verb, for example 'p' or 's', zero if the error is not related to a verb
+	Name     string // name of a named verb
+	Err      error  // underlying error
+}
+
+// Error implements the error interface
+func (e *FormatError) Error() string {
+	var s = "qp: " + e.Err.Error() +
+		" (fragment " + strconv.Itoa(e.Fragment) +
+		", offset " + strconv.Itoa(e.Offset)
+	if e.Verb != 0 {
+		s += ", verb %" + string(e.Verb)
+	}
+	if e.Name != "" {
+		s += ", name " + e.Name
+	}
+	return s + ")"
+}
+
+// Unwrap returns the underlying error
+func (e *FormatError) Unwrap() error {
+	return e.Err
+}
+
+// unsupported returns ErrUnsupportedValue wrapped with a description of x
+func unsupported(x interface{}) error {
+	return fmt.Errorf("%w %T(%v)", ErrUnsupportedValue, x, x)
+}
@@ -2,25 +2,98 @@ package qp
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
+	"sync"
+	"unsafe"
 )
 
 var (
+	mu      sync.RWMutex
 	driver  = PgsqlDriver
 	drivers = map[string]func() Driver{}
 )
 
 type (
 	// Driver interface
+	// Placeholder returns placeholders for x
 	Driver interface {
 		Placeholder(x interface{}) string
 	}
 
+	// Positional is an optional Driver interface for placeholders which depend on their position,
+	// n is the number of parameters bound before x in the query
+	// It is used instead of Placeholder, so a driver needs no state between calls
+	// and can be shared by concurrent renders
+	Positional interface {
+		PlaceholderAt(n int, x interface{}) string
+	}
+
+	// Pager is an optional Driver interface for a dialect specific paging clause,
+	// drivers without it use "LIMIT %p OFFSET %p"
+	Pager interface {
+		Paging(limit, offset interface{}) Formatter
+	}
+
+	// Numbered is an optional Driver interface for drivers with numbered placeholders,
+	// such drivers refer to a repeated named parameter by the same placeholder
+	// Oracle binds every placeholder by position even if numbers repeat, so its driver is not Numbered
+	Numbered interface {
+		Numbered() bool
+	}
+
+	// Quoter is an optional Driver interface for identifier quoting,
+	// drivers without it quote identifiers with double quotes
+	Quoter interface {
+		QuoteIdent(name string) string
+	}
+
+	// LiteralEncoder is an optional Driver interface for encoding values as sql literals,
+	// drivers without it use standard sql literals
+	LiteralEncoder interface {
+		EncodeLiteral(x interface{}) (string, error)
+	}
+
+	// ParamLimiter is an optional Driver interface for the maximum number of parameters in a statement,
+	// drivers without it have no limit
+	ParamLimiter interface {
+		MaxParams() int
+	}
+
+	// Upserter is an optional Driver interface for a dialect specific upsert statement,
+	// drivers without it use "INSERT ... ON CONFLICT"
+	Upserter interface {
+		Upsert(u *Upsert) Formatter
+	}
+
+	// NamedPlaceholder is an optional Driver interface for drivers which bind a sql.NamedArg by name,
+	// drivers without it bind the value of a sql.NamedArg as a positional parameter
+	NamedPlaceholder interface {
+		NamedPlaceholder(name string) string
+	}
+
+	// ParamConverter is an optional Driver interface for a dialect specific conversion of parameters,
+	// Build applies it to every parameter, the value of a sql.NamedArg is converted in place
+	ParamConverter interface {
+		ConvertParam(x interface{}) (interface{}, error)
+	}
+
+	// StringifierDriver is an optional Driver interface for a dialect specific Stringifier of the %s verb,
+	// drivers without it use DefaultStringifier
+	StringifierDriver interface {
+		Stringifier() *Stringifier
+	}
+
+	// Expander is implemented by collections which are expanded into several parameters,
+	// Expand returns false if the value is a single parameter
+	// Slices and arrays are expanded without it, except []byte like values and driver.Valuer
+	Expander interface {
+		Expand() ([]interface{}, bool)
+	}
+
 	// Formatter interface
 	Formatter interface {
 		String() string
 		Params() []interface{}
+		Build() (string, []interface{}, error)
 		Format(format string, params ...interface{}) Formatter
 		Driver(driver Driver) Formatter
 		Jumper(jumper string) Formatter
@@ -28,27 +101,50 @@ type (
 
 	// Formatter implements a Formatter interface
 	formatter struct {
-		format []string
+		format []*Template
 		params [][]interface{}
 		driver Driver
 		jumper string
-		master bool
+		str    *Stringifier
 	}
 )
 
 // DefaultDriver sets a default driver
+// It panics if the driver is not registered
 func DefaultDriver(name string) {
-	var ok bool
-	if driver, ok = drivers[name]; !ok {
-		panic("qp: driver '" + name + "' not found")
+	if err := SetDefaultDriver(name); err != nil {
+		panic(err)
+	}
+}
+
+// SetDefaultDriver sets a default driver
+// It returns an error if the driver is not registered
+func SetDefaultDriver(name string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	d, ok := drivers[name]
+	if !ok {
+		return fmt.Errorf("qp: %w '%s'", ErrDriverNotFound, name)
 	}
+	driver = d
+	return nil
 }
 
 // RegisterDriver registers a new driver
 func RegisterDriver(name string, driver func() Driver) {
+	mu.Lock()
+	defer mu.Unlock()
 	drivers[name] = driver
 }
 
+// defaultDriver returns a new default driver
+func defaultDriver() Driver {
+	mu.RLock()
+	var d = driver
+	mu.RUnlock()
+	return d()
+}
+
 // New returns a new empty formatter
 //		var values = qp.New().Jumper(", ")
 //		values.Format("(%+p)", 1, "Tom", 12)
@@ -59,9 +155,8 @@ func RegisterDriver(name string, driver func() Driver) {
 //		_ = query.Params() // [1, "Tom", 12, 2, "Huckleberry", 13]
 func New() Formatter {
 	return &formatter{
-		format: []string{},
+		format: []*Template{},
 		params: [][]interface{}{},
-		driver: driver(),
 		jumper: " AND ",
 	}
 }
@@ -72,138 +167,188 @@ func New() Formatter {
 //		_ = query.Params() // ["Tom", 10, 0]
 func Format(format string, params ...interface{}) Formatter {
 	return &formatter{
-		format: []string{format},
+		format: []*Template{compile(format)},
 		params: [][]interface{}{params},
-		driver: driver(),
 		jumper: " AND ",
 	}
 }
 
 // String returns a query string
+// It panics if the format is invalid, use Build to get an error instead
 func (f *formatter) String() string {
-	defer f.m()
-	var (
-		b strings.Builder
-		p int
-		i int
-		j int
-		l int
-		r bool
-		s bool
-	)
-	for n, format := range f.format {
-		if n > 0 {
-			b.WriteString(f.jumper)
-		}
-		for i, j, p = 0, 0, 0; i < len(format); i++ {
-			switch {
-			case format[i] == '%':
-				if l, r = btoi(!s), !r; !r {
-					b.WriteString(format[j : i-l+1])
-					j = i + 1
-					s = false
-				}
-			case format[i] == '+' && r:
-				s = true
-				l = l + 1
-			case format[i] == 's' && r:
-				if p >= len(f.params[n]) {
-					panic("qp: parameter not found")
-				}
-				b.WriteString(format[j : i-l])
-				b.WriteString(f.s(n, p, s))
-				if s {
-					p = len(f.params[n])
-				}
-				p = p + 1
-				j = i + 1
-				r = false
-				s = false
-			case format[i] == 'p' && r:
-				if p >= len(f.params[n]) {
-					panic("qp: parameter not found")
-				}
-				b.WriteString(format[j : i-l])
-				b.WriteString(f.p(n, p, s))
-				if s {
-					p = len(f.params[n])
-				}
-				p = p + 1
-				j = i + 1
-				r = false
-				s = false
+	s, _, err := f.Build()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Params returns parameters for query
+// It panics if the format is invalid, use Build to get an error instead
+func (f *formatter) Params() []interface{} {
+	_, params, err := f.Build()
+	if err != nil {
+		panic(err)
+	}
+	return params
+}
+
+// Build returns a query string and parameters for query
+// The query string and parameters are produced in a single pass,
+// the formatter is not modified, so it can be built concurrently
+// It returns a *FormatError if a verb has no matching parameter
+//		var query, params, err = qp.Format("SELECT id FROM table WHERE name = %p", "Tom").Build()
+//		_ = query  // SELECT id FROM table WHERE name = $1
+//		_ = params // ["Tom"]
+func (f *formatter) Build() (string, []interface{}, error) {
+	var n, m = f.size()
+	var p = printer{
+		buf:    make([]byte, 0, n),
+		params: make([]interface{}, 0, m),
+		driver: f.d(),
+		str:    f.str,
+	}
+	if err := f.print(&p); err != nil {
+		return "", nil, err
+	}
+	if err := p.convert(); err != nil {
+		return "", nil, err
+	}
+	// the buffer is not used after the render, so it becomes the string without a copy
+	return *(*string)(unsafe.Pointer(&p.buf)), p.params, nil
+}
+
+// size estimates the length of the query string and the number of parameters,
+// so a render allocates its buffers once
+func (f *formatter) size() (n, m int) {
+	for i, t := range f.format {
+		n += len(t.format) + len(f.jumper)
+		for _, x := range f.params[i] {
+			switch x := x.(type) {
+			case *formatter:
+				var a, b = x.size()
+				n, m = n+a, m+b
+			case []interface{}:
+				n, m = n+4*len(x), m+len(x)
+			case []int:
+				n, m = n+4*len(x), m+len(x)
+			case []int64:
+				n, m = n+4*len(x), m+len(x)
+			case []string:
+				n, m = n+4*len(x), m+len(x)
 			default:
-				r = false
-				s = false
+				n, m = n+4, m+1
 			}
 		}
-		b.WriteString(format[j:])
 	}
-	return b.String()
+	return n, m
 }
 
-// Params returns parameters for query
-func (f *formatter) Params() []interface{} {
+// print renders the formatter into the printer
+func (f *formatter) print(p *printer) (err error) {
 	var (
-		params = make([]interface{}, 0, len(f.params))
-		record = false
-		spread = false
+		w     = (*Writer)(p)
+		table = verbs.Load().(*verbTable)
 	)
 	for n, format := range f.format {
-		for i, p := 0, 0; i < len(format); i++ {
+		if n > 0 {
+			p.buf = append(p.buf, f.jumper...)
+		}
+		var (
+			params = f.params[n]
+			names  = namesOf(params)
+			bound  map[token]string
+			k      int
+			m      int
+		)
+		for _, t := range format.tokens {
+			p.buf = append(p.buf, t.text...)
+			if t.verb == 0 {
+				continue
+			}
+			// verbs are looked up at render, an unregistered one is text
+			var verb = table[t.verb]
+			if verb == nil {
+				p.buf = append(p.buf, t.raw...)
+				continue
+			}
+			if t.index > 0 {
+				k = t.index - 1
+			}
+			var (
+				arg interface{}
+				ok  bool
+				at  = k
+			)
 			switch {
-			case format[i] == '%':
-				if record = !record; !record {
-					spread = false
+			case t.name != "":
+				arg, ok = names[t.name]
+			case names == nil && k < len(params):
+				arg, ok = params[k], true
+				if t.spread {
+					arg, k = params[k:], len(params)
+				} else {
+					k = k + 1
 				}
-			case format[i] == '+' && record:
-				spread = true
-			case format[i] == 's' && record:
-				if p >= len(f.params[n]) {
-					panic("qp: parameter not found")
+				if m < k {
+					m = k
 				}
-				if spread {
-					params = filters(params, f.params[n][p:]...)
-					p = len(f.params[n])
-				} else {
-					params = filters(params, f.params[n][p])
-					p = p + 1
+			}
+			if !ok {
+				return &FormatError{Fragment: n, Offset: t.offset, Verb: t.verb, Name: t.name, Err: ErrParamNotFound}
+			}
+			if format.refs && p.numbered() {
+				// a repeated parameter refers to the same placeholders, whatever verb wrote them
+				var key = token{verb: t.verb, name: t.name, spread: t.spread}
+				if t.name == "" {
+					key.index = at + 1
 				}
-				record = false
-				spread = false
-			case format[i] == 'p' && record:
-				if p >= len(f.params[n]) {
-					panic("qp: parameter not found")
+				if s, ok := bound[key]; ok {
+					p.buf = append(p.buf, s...)
+					continue
 				}
-				if spread {
-					params = insert(params, f.params[n][p:]...)
-					p = len(f.params[n])
-				} else {
-					params = insert(params, f.params[n][p])
-					p = p + 1
+				var i, j = len(p.buf), len(p.params)
+				err = verb(w, arg)
+				if err == nil && len(p.params) > j {
+					if bound == nil {
+						bound = map[token]string{}
+					}
+					bound[key] = string(p.buf[i:])
 				}
-				record = false
-				spread = false
-			default:
-				record = false
-				spread = false
+			} else {
+				err = verb(w, arg)
+			}
+			if err != nil {
+				if _, ok := err.(*FormatError); !ok {
+					err = &FormatError{Fragment: n, Offset: t.offset, Verb: t.verb, Name: t.name, Err: err}
+				}
+				return err
 			}
 		}
+		if format.strict && names == nil && m < len(params) {
+			return &FormatError{Fragment: n, Offset: len(format.format), Err: ErrTooManyParams}
+		}
 	}
-	return params
+	return nil
 }
 
 // Format formats according to a format specifier and returns the sql query string
 func (f *formatter) Format(format string, params ...interface{}) Formatter {
 	f.params = append(f.params, params)
-	f.format = append(f.format, format)
+	f.format = append(f.format, compile(format))
 	return f
 }
 
+// bind appends a fragment of the template with parameters
+func (f *formatter) bind(t *Template, params []interface{}) {
+	f.params = append(f.params, params)
+	f.format = append(f.format, t)
+}
+
 // Driver sets a Driver
+// A nested formatter is always rendered with a Driver of the outer formatter
 func (f *formatter) Driver(driver Driver) Formatter {
 	f.driver = driver
-	f.master = true
 	return f
 }
 
@@ -214,101 +359,17 @@ func (f *formatter) Jumper(jumper string) Formatter {
 	return f
 }
 
-func (f *formatter) s(n, p int, s bool) string {
-	switch s {
-	case true:
-		return f.toString(f.params[n][p:])
-	default:
-		return f.toString(f.params[n][p])
-	}
+func (f *formatter) setStringifier(s *Stringifier) {
+	f.str = s
 }
 
-func (f *formatter) p(n, p int, s bool) string {
-	switch s {
-	case true:
-		return f.d().Placeholder(f.params[n][p:])
-	default:
-		return f.d().Placeholder(f.params[n][p])
-	}
+func (f *formatter) s() *Stringifier {
+	return f.str
 }
 
 func (f *formatter) d() Driver {
 	if f.driver == nil {
-		f.driver = driver()
+		return defaultDriver()
 	}
 	return f.driver
 }
-
-func (f *formatter) m() {
-	if !f.master {
-		f.driver = driver()
-	}
-}
-
-// ToString converts an interface to string
-func (f *formatter) toString(x interface{}) string {
-	switch x := x.(type) {
-	case string:
-		return x
-	case Formatter:
-		return x.Driver(f.d()).String()
-	case fmt.Stringer:
-		return x.String()
-	case int:
-		return strconv.FormatInt(int64(x), 10)
-	case int8:
-		return strconv.FormatInt(int64(x), 10)
-	case int16:
-		return strconv.FormatInt(int64(x), 10)
-	case int32:
-		return strconv.FormatInt(int64(x), 10)
-	case int64:
-		return strconv.FormatInt(int64(x), 10)
-	case uint:
-		return strconv.FormatUint(uint64(x), 10)
-	case uint8:
-		return strconv.FormatUint(uint64(x), 10)
-	case uint16:
-		return strconv.FormatUint(uint64(x), 10)
-	case uint32:
-		return strconv.FormatUint(uint64(x), 10)
-	case uint64:
-		return strconv.FormatUint(uint64(x), 10)
-	case float32:
-		return strconv.FormatFloat(float64(x), 'f', 6, 32)
-	case float64:
-		return strconv.FormatFloat(x, 'f', 6, 64)
-	case []byte:
-		return string(x)
-	case []rune:
-		return string(x)
-	case []int:
-		return intsToString(x)
-	case []int64:
-		return int64sToString(x)
-	case []string:
-		return stringsToString(x)
-	case []interface{}:
-		return f.strings(x)
-	case nil:
-		return ""
-	default:
-		return fmt.Sprint(x)
-	}
-}
-
-// strings converts []interface to string
-// For example: []interface{"name", "surname", []string{"age"}} => "name, surname, age"
-func (f *formatter) strings(x []interface{}) string {
-	var n int
-	if n = len(x); n == 0 {
-		return ""
-	}
-	var b strings.Builder
-	b.WriteString(f.toString(x[0]))
-	for i := 1; i < n; i++ {
-		b.WriteString(", ")
-		b.WriteString(f.toString(x[i]))
-	}
-	return b.String()
-}
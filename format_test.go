@@ -1,7 +1,15 @@
 package qp
 
 import (
+	"database/sql"
+	sqldriver "database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -350,6 +358,380 @@ func TestFormatter_Verbs6(t *testing.T) {
 	)
 }
 
+func TestFormatter_Build(t *testing.T) {
+	b := Format("name = %p", "Tom")
+	q := Format(
+		"SELECT id FROM table WHERE %s LIMIT %p",
+		b, 10,
+	)
+	s, p, err := q.Build()
+	assert.NoError(t, err)
+	assert.Equal(t,
+		`SELECT id FROM table WHERE name = $1 LIMIT $2`,
+		s,
+	)
+	assert.Equal(t,
+		[]interface{}{"Tom", 10},
+		p,
+	)
+}
+
+func TestFormatter_BuildError(t *testing.T) {
+	var err error
+
+	_, _, err = Format("id = %p").Build()
+	assert.Equal(t, &FormatError{Fragment: 0, Offset: 5, Verb: 'p', Err: ErrParamNotFound}, err)
+	assert.EqualError(t, err, "qp: parameter not found (fragment 0, offset 5, verb %p)")
+
+	_, _, err = Format("id = %p", 1).Format("name = %+s").Build()
+	assert.Equal(t, &FormatError{Fragment: 1, Offset: 7, Verb: 's', Err: ErrParamNotFound}, err)
+
+	_, _, err = Format("WHERE %s", Format("%p, %p", 1)).Build()
+	assert.Equal(t, &FormatError{Fragment: 0, Offset: 4, Verb: 'p', Err: ErrParamNotFound}, err)
+	assert.True(t, errors.Is(err, ErrParamNotFound))
+
+	assert.Panics(t, func() { _ = Format("id = %p").String() })
+	assert.Panics(t, func() { _ = Format("id = %p").Params() })
+}
+
+func TestFormatter_BuildNested(t *testing.T) {
+	b1 := Format("a = %p", 1).Format("b IN (%p)", []int{2, 3})
+	b2 := Format("(%s)", b1).Format("c = %p", 4).Jumper(" OR ")
+	q := Format(
+		"SELECT id FROM table WHERE %s AND d IN (%s, %s) LIMIT %p",
+		b2, Format("%p", 5), []interface{}{Format("%p", 6)}, 7,
+	)
+	s, p, err := q.Build()
+	assert.NoError(t, err)
+	assert.Equal(t,
+		`SELECT id FROM table WHERE (a = $1 AND b IN ($2, $3)) OR c = $4 AND d IN ($5, $6) LIMIT $7`,
+		s,
+	)
+	assert.Equal(t,
+		[]interface{}{1, 2, 3, 4, 5, 6, 7},
+		p,
+	)
+	assert.Equal(t, s, q.String())
+	assert.Equal(t, p, q.Params())
+}
+
+func TestFormatter_Index(t *testing.T) {
+	q := Format(
+		"SELECT %[3]s FROM table WHERE name = %[1]p OR nick = %[1]p AND age > %p AND id IN (%[4]+p)",
+		"Tom", 12, "id", 1, 2,
+	)
+	assert.Equal(t,
+		`SELECT id FROM table WHERE name = $1 OR nick = $1 AND age > $2 AND id IN ($3, $4)`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{"Tom", 12, 1, 2},
+		q.Params(),
+	)
+
+	q.Driver(MysqlDriver())
+	assert.Equal(t,
+		`SELECT id FROM table WHERE name = ? OR nick = ? AND age > ? AND id IN (?, ?)`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{"Tom", "Tom", 12, 1, 2},
+		q.Params(),
+	)
+
+	q.Driver(OracleDriver())
+	assert.Equal(t,
+		`SELECT id FROM table WHERE name = :1 OR nick = :2 AND age > :3 AND id IN (:4, :5)`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{"Tom", "Tom", 12, 1, 2},
+		q.Params(),
+	)
+
+	_, _, err := Format("id = %[2]p", 1).Build()
+	assert.Equal(t, &FormatError{Offset: 5, Verb: 'p', Err: ErrParamNotFound}, err)
+}
+
+func TestFormatter_Ident(t *testing.T) {
+	q := Format(
+		"SELECT %i, %i, %i FROM %i WHERE %i = %p ORDER BY %i",
+		"t.*", "public.users.name", []string{"age", `my"col`}, "public.users", "id", 1, Format("id DESC"),
+	)
+	assert.Equal(t,
+		`SELECT "t".*, "public"."users"."name", "age", "my""col" FROM "public"."users" WHERE "id" = $1 ORDER BY id DESC`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{1},
+		q.Params(),
+	)
+
+	q = Format("SELECT %i FROM %i", []interface{}{"id", 12}, "public.users")
+	assert.Equal(t,
+		"SELECT `id`, `12` FROM `public`.`users`",
+		q.Driver(MysqlDriver()).String(),
+	)
+	assert.Equal(t,
+		`SELECT [id], [12] FROM [public].[users]`,
+		q.Driver(SqlserverDriver()).String(),
+	)
+	assert.Equal(t,
+		`SELECT "id", "12" FROM "public"."users"`,
+		q.Driver(testDriver{}).String(),
+	)
+
+	q = Format("INSERT INTO users (%+i)", "id", "name")
+	assert.Equal(t,
+		`INSERT INTO users ("id", "name")`,
+		q.String(),
+	)
+}
+
+func TestFormatter_Literal(t *testing.T) {
+	q := Format(
+		"COMMENT ON TABLE %i IS %l; ALTER TABLE %i ALTER COLUMN %i SET DEFAULT %l; SELECT %+l",
+		"users", "it's users", "users", "active", false, nil, 1, []string{"a", "b"}, Format("%p", 2),
+	)
+	assert.Equal(t,
+		`COMMENT ON TABLE "users" IS 'it''s users'; ALTER TABLE "users" ALTER COLUMN "active" SET DEFAULT FALSE; SELECT NULL, 1, 'a', 'b', $1`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{2},
+		q.Params(),
+	)
+
+	_, _, err := Format("SELECT %l", map[string]int{}).Build()
+	assert.True(t, errors.Is(err, ErrUnsupportedValue))
+	assert.EqualError(t, err, "qp: unsupported value map[string]int(map[]) (fragment 0, offset 7, verb %l)")
+}
+
+func TestFormatter_Concurrency(t *testing.T) {
+	b := Format("name = %p", "Tom").Format("age IN (%p)", []int{18, 21})
+	q := Format("SELECT id FROM table WHERE %s LIMIT %p", b, 10)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			s, p, err := q.Build()
+			assert.NoError(t, err)
+			assert.Equal(t, `SELECT id FROM table WHERE name = $1 AND age IN ($2, $3) LIMIT $4`, s)
+			assert.Equal(t, []interface{}{"Tom", 18, 21, 10}, p)
+		}()
+		go func() {
+			defer wg.Done()
+			s, _, err := Format("SELECT id FROM table WHERE %s", b).Driver(MysqlDriver()).Build()
+			assert.NoError(t, err)
+			assert.Equal(t, `SELECT id FROM table WHERE name = ? AND age IN (?, ?)`, s)
+		}()
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, SetDefaultDriver("postgres"))
+		}()
+	}
+	wg.Wait()
+}
+
+type (
+	testID   int
+	testIDs  []testID
+	testUUID [16]byte
+	testList []string
+	testSet  struct{ ids []int }
+)
+
+// Expand opts the slice out, it is bound as one parameter
+func (x testList) Expand() ([]interface{}, bool) {
+	return nil, false
+}
+
+// Value implements the driver.Valuer interface
+func (x testList) Value() (sqldriver.Value, error) {
+	return strings.Join(x, ","), nil
+}
+
+// Expand opts the struct in
+func (x testSet) Expand() ([]interface{}, bool) {
+	var xs = make([]interface{}, len(x.ids))
+	for i := range x.ids {
+		xs[i] = x.ids[i]
+	}
+	return xs, true
+}
+
+func TestFormatter_Expand(t *testing.T) {
+	q := Format(
+		"SELECT id FROM users WHERE id IN (%p) AND group_id IN (%p) AND uuid IN (%p) AND tags = %p AND data = %p AND score IN (%p) AND %s AND parent_id IN (%p)",
+		testIDs{1, 2}, [2]uint32{3, 4}, []testUUID{{1}, {2}}, testList{"a", "b"}, json.RawMessage(`{}`), []float64{0.5}, Format("x IN (%+p)", []int8{5}, 6), testSet{ids: []int{7, 8}},
+	)
+	assert.Equal(t,
+		"SELECT id FROM users WHERE id IN ($1, $2) AND group_id IN ($3, $4) AND uuid IN ($5, $6) AND tags = $7 AND data = $8 AND score IN ($9) AND x IN ($10, $11) AND parent_id IN ($12, $13)",
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{
+			testID(1), testID(2), uint32(3), uint32(4), testUUID{1}, testUUID{2}, testList{"a", "b"}, json.RawMessage(`{}`), 0.5, int8(5), 6, 7, 8,
+		},
+		q.Params(),
+	)
+
+	q = Format("SELECT %i FROM users WHERE id IN (%l)", [2]string{"id", "name"}, testIDs{1, 2})
+	assert.Equal(t, `SELECT "id", "name" FROM users WHERE id IN (1, 2)`, q.String())
+
+	q = Format("SELECT id FROM users WHERE id IN (%p)", testIDs{}).Driver(MysqlDriver())
+	assert.Equal(t, `SELECT id FROM users WHERE id IN ()`, q.String())
+	assert.Equal(t, []interface{}{}, q.Params())
+}
+
+func TestFormatter_NamedArg(t *testing.T) {
+	q := Format("SELECT id FROM users WHERE id = %p AND name IN (%p)", sql.Named("id", 5), []interface{}{sql.Named("a", "Tom"), "Huck"})
+	assert.Equal(t, `SELECT id FROM users WHERE id = $1 AND name IN ($2, $3)`, q.String())
+	assert.Equal(t, []interface{}{5, "Tom", "Huck"}, q.Params())
+
+	q = Format("SELECT id FROM users WHERE id = %p", sql.Named("id", 5)).Driver(OracleDriver())
+	assert.Equal(t, `SELECT id FROM users WHERE id = :id`, q.String())
+	assert.Equal(t, []interface{}{sql.Named("id", 5)}, q.Params())
+
+	q = Format("SELECT id FROM users WHERE id = %l AND %s", sql.Named("id", 5), sql.Named("name", "x = 1"))
+	assert.Equal(t, `SELECT id FROM users WHERE id = 5 AND x = 1`, q.String())
+
+	assert.Equal(t,
+		`/* qp: interpolated, not for execution */ SELECT id FROM users WHERE id = 5`,
+		Interpolate(Format("SELECT id FROM users WHERE id = %p", sql.Named("id", 5)).Driver(SqlserverDriver())),
+	)
+}
+
+func TestFormatter_Valuer(t *testing.T) {
+	var null *testValuer
+	q := Format("SELECT %s, %l, %l, %l FROM users", testValuer("id"), testValuer("it's"), null, testList{"a", "b"})
+	assert.Equal(t, `SELECT id, 'it''s', NULL, 'a,b' FROM users`, q.String())
+
+	_, _, err := Format("SELECT %l", testValuer("")).Build()
+	assert.True(t, errors.Is(err, errTestValuer))
+}
+
+// testValuer is a driver.Valuer, an empty value fails
+type testValuer string
+
+var errTestValuer = errors.New("empty value")
+
+func (x testValuer) Value() (sqldriver.Value, error) {
+	if x == "" {
+		return nil, errTestValuer
+	}
+	return string(x), nil
+}
+
+// testConvertDriver is a Driver which binds empty strings as NULL, rejects negative ints and binds sql.NamedArg by name
+type testConvertDriver struct {
+	testDriver
+}
+
+var errTestNegative = errors.New("negative")
+
+func (testConvertDriver) NamedPlaceholder(name string) string {
+	return ":" + name
+}
+
+func (testConvertDriver) ConvertParam(x interface{}) (interface{}, error) {
+	switch x := x.(type) {
+	case string:
+		if x == "" {
+			return nil, nil
+		}
+	case int:
+		if x < 0 {
+			return nil, errTestNegative
+		}
+	}
+	return x, nil
+}
+
+func TestFormatter_ConvertParam(t *testing.T) {
+	q := And(Format("name = %p", ""), Format("id IN (%p)", []int{1, 2})).Driver(testConvertDriver{})
+	assert.Equal(t, `name = ? AND id IN (?, ?)`, q.String())
+	assert.Equal(t, []interface{}{nil, 1, 2}, q.Params())
+
+	q = Format("SELECT id FROM users WHERE name = %p AND nick = %p", "Tom", sql.Named("nick", "")).Driver(testConvertDriver{})
+	assert.Equal(t, `SELECT id FROM users WHERE name = ? AND nick = :nick`, q.String())
+	assert.Equal(t, []interface{}{"Tom", sql.Named("nick", nil)}, q.Params())
+
+	_, _, err := Format("SELECT id FROM users WHERE id = %p", -1).Driver(testConvertDriver{}).Build()
+	assert.True(t, errors.Is(err, errTestNegative))
+	assert.EqualError(t, err, "qp: negative (parameter 1)")
+
+	_, _, err = Or(Format("id = %p", -1)).Driver(testConvertDriver{}).Build()
+	assert.True(t, errors.Is(err, errTestNegative))
+}
+
+func TestFormatter_Stringifier(t *testing.T) {
+	var s = DefaultStringifier()
+	s.Null = ""
+	s.Float = func(f float64, bits int) string { return strconv.FormatFloat(f, 'f', 2, bits) }
+	s.Time = func(t time.Time) string { return t.Format("2006-01-02") }
+	s.Hook(testID(0), func(x interface{}) string { return "id_" + strconv.Itoa(int(x.(testID))) })
+
+	var date = time.Date(2020, 1, 24, 10, 0, 0, 0, time.UTC)
+	q := WithStringifier(Format("%s %s %s [%s] %s %s", 0.125, date, testID(7), nil, Format("%s", 1.5), true), s)
+	assert.Equal(t, `0.12 2020-01-24 id_7 [] 1.50 TRUE`, q.String())
+
+	// a nested formatter with its own Stringifier keeps it
+	q = Format("%s %s", WithStringifier(Format("%s", 1.5), s), And(Format("x = %s", 0.5)))
+	assert.Equal(t, `1.50 x = 0.5`, q.String())
+
+	q = WithStringifier(Format("%s %s", WithStringifier(Format("%s", 1.5), DefaultStringifier()), 0.5), s)
+	assert.Equal(t, `1.5 0.50`, q.String())
+
+	q = WithStringifier(And(Format("x = %s", 0.5), Format("y = %s", false)), s)
+	assert.Equal(t, `x = 0.50 AND y = FALSE`, q.String())
+	assert.Equal(t, `/* qp: interpolated, not for execution */ x = 0.50 AND y = FALSE`, Interpolate(q))
+
+	q = WithStringifier(Format("%s %s", 0.5, true), &Stringifier{Null: "null"})
+	assert.Equal(t, `0.5 TRUE`, q.String())
+
+	q = Format("SELECT %s, %s", true, nil).Driver(SqlserverDriver())
+	assert.Equal(t, `SELECT 1, NULL`, q.String())
+
+	// a foreign formatter is returned as is
+	var w = testWrapped{Format("%s", 0.5)}
+	assert.Equal(t, w, WithStringifier(w, s))
+}
+
+// testWrapped is a Formatter which is not made by the package
+type testWrapped struct {
+	Formatter
+}
+
+func TestFormatter_Foreign(t *testing.T) {
+	_, _, err := Format("a = %p AND %s", 1, testWrapped{Format("b = %p", 2)}).Build()
+	assert.True(t, errors.Is(err, ErrForeignFormatter))
+	assert.Equal(t, &FormatError{Offset: 11, Verb: 's', Err: ErrForeignFormatter}, err)
+
+	_, _, err = And(Format("a = %p", 1), testWrapped{Format("b = %p", 2)}).Driver(MysqlDriver()).Build()
+	assert.True(t, errors.Is(err, ErrForeignFormatter))
+
+	q := Format("a = %p AND %s", 1, testWrapped{Format("b IS NULL")})
+	assert.Equal(t, `a = $1 AND b IS NULL`, q.String())
+	assert.Equal(t, []interface{}{1}, q.Params())
+}
+
+func TestSetDefaultDriver(t *testing.T) {
+	err := SetDefaultDriver("unknown")
+	assert.True(t, errors.Is(err, ErrDriverNotFound))
+	assert.EqualError(t, err, "qp: driver not found 'unknown'")
+	assert.Panics(t, func() { DefaultDriver("unknown") })
+}
+
+// testDriver is a Driver without optional interfaces
+type testDriver struct{}
+
+func (testDriver) Placeholder(x interface{}) string {
+	return repeated("?", x)
+}
+
 func TestUtils_toString(t *testing.T) {
 	var testCases = []struct {
 		name   string
@@ -424,22 +806,75 @@ func TestUtils_toString(t *testing.T) {
 			name:   "case_interfaces",
 			input:  []interface{}{1, "s", []int{2, 3}, []interface{}{4, "t", []int64{5, 6}}},
 			output: "1, s, 2, 3, 4, t, 5, 6",
+		}, {
+			name:   "case_floats",
+			input:  []float64{1.5, 2},
+			output: "1.5, 2",
+		}, {
+			name:   "case_array",
+			input:  [3]uint32{1, 2, 3},
+			output: "1, 2, 3",
+		}, {
+			name:   "case_raw_message",
+			input:  json.RawMessage(`{"a":1}`),
+			output: `{"a":1}`,
+		}, {
+			name:   "case_expander",
+			input:  testIDs{1, 2},
+			output: "1, 2",
+		}, {
+			name:   "case_float",
+			input:  0.1234567,
+			output: "0.1234567",
+		}, {
+			name:   "case_float_exp",
+			input:  1e20,
+			output: "1e+20",
+		}, {
+			name:   "case_float32",
+			input:  float32(0.1),
+			output: "0.1",
+		}, {
+			name:   "case_bool",
+			input:  true,
+			output: "TRUE",
+		}, {
+			name:   "case_time",
+			input:  time.Date(2020, 1, 24, 0, 0, 0, 500, time.UTC),
+			output: "2020-01-24T00:00:00.0000005Z",
+		}, {
+			name:   "case_null_valuer",
+			input:  sql.NullString{},
+			output: "NULL",
 		}, {
 			name:   "case_nil",
 			input:  nil,
-			output: "",
+			output: "NULL",
 		},
 	}
 
-	var format = new(formatter)
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
-			var output = format.toString(tt.input)
-			assert.Equal(t, tt.output, output)
+			var p = printer{driver: PgsqlDriver()}
+			assert.NoError(t, p.text(tt.input))
+			assert.Equal(t, tt.output, string(p.buf))
 		})
 	}
 }
 
+func TestUtils_expand(t *testing.T) {
+	type myInt int
+	for _, x := range []interface{}{nil, "a", []byte("a"), true, time.Now(), 1, int64(1), uint8(1), 1.5, myInt(1), testValuer("a")} {
+		xs, ok := expand(x)
+		assert.False(t, ok)
+		assert.Nil(t, xs)
+	}
+
+	xs, ok := expand([2]myInt{1, 2})
+	assert.True(t, ok)
+	assert.Equal(t, []interface{}{myInt(1), myInt(2)}, xs)
+}
+
 func BenchmarkBuilder_FormatString(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		var b = Format("name = %p", "Tom").
@@ -457,3 +892,23 @@ func BenchmarkBuilder_FormatParams(b *testing.B) {
 		_ = Format(`SELECT id FROM table WHERE %s LIMIT %p`, b, 10).Params()
 	}
 }
+
+func BenchmarkBuilder_FormatBuild(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		var b = Format("name = %p", "Tom").
+			Format("age = %p", []int64{18, 21, 30})
+
+		_, _, _ = Format(`SELECT id FROM table WHERE %s LIMIT %p`, b, 10).Build()
+	}
+}
+
+func BenchmarkBuilder_Build(b *testing.B) {
+	var q = Format(
+		`SELECT id FROM table WHERE %s LIMIT %p`,
+		Format("name = %p", "Tom").Format("age = %p", []int64{18, 21, 30}), 10,
+	)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = q.Build()
+	}
+}
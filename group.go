@@ -0,0 +1,204 @@
+package qp
+
+// group is a Formatter of conditions joined by a logical operator
+// Nested groups are parenthesised, empty conditions are dropped, empty nested groups are neutral elements
+type group struct {
+	jumper     string
+	neutral    string // written if there are no conditions
+	not        bool
+	conditions []Formatter
+	driver     Driver
+	str        *Stringifier
+}
+
+// And returns a Formatter of conditions joined by AND, "1=1" if there are no conditions
+// Nil and empty conditions are dropped, nested Or and Not are parenthesised,
+// an empty nested group is its neutral element
+//		var query = qp.And(qp.Format("a = %p", 1), qp.Or(qp.Format("b = %p", 2), qp.Format("c = %p", 3)))
+//		_ = query.String() // a = $1 AND (b = $2 OR c = $3)
+//		_ = qp.And().String() // 1=1
+func And(conditions ...Formatter) Formatter {
+	return &group{jumper: " AND ", neutral: "1=1", conditions: conditions}
+}
+
+// Or returns a Formatter of conditions joined by OR, "1=0" if there are no conditions
+// Nil and empty conditions are dropped, nested And and Not are parenthesised,
+// an empty nested group is its neutral element
+func Or(conditions ...Formatter) Formatter {
+	return &group{jumper: " OR ", neutral: "1=0", conditions: conditions}
+}
+
+// Not returns a Formatter of the negated conditions joined by AND, "1=1" if there are no conditions
+//		_ = qp.Not(qp.Format("a = %p", 1), qp.Format("b = %p", 2)).String() // NOT (a = $1 AND b = $2)
+func Not(conditions ...Formatter) Formatter {
+	return &group{jumper: " AND ", neutral: "1=1", not: true, conditions: conditions}
+}
+
+// String returns a query string
+// It panics if the format is invalid, use Build to get an error instead
+func (g *group) String() string {
+	s, _, err := g.Build()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Params returns parameters for query
+// It panics if the format is invalid, use Build to get an error instead
+func (g *group) Params() []interface{} {
+	_, params, err := g.Build()
+	if err != nil {
+		panic(err)
+	}
+	return params
+}
+
+// Build returns a query string and parameters for query
+func (g *group) Build() (string, []interface{}, error) {
+	var p = printer{driver: g.d(), str: g.str}
+	if err := g.top(&p); err != nil {
+		return "", nil, err
+	}
+	if err := p.convert(); err != nil {
+		return "", nil, err
+	}
+	return string(p.buf), p.params, nil
+}
+
+// Format appends a condition
+func (g *group) Format(format string, params ...interface{}) Formatter {
+	g.conditions = append(g.conditions, Format(format, params...))
+	return g
+}
+
+// Driver sets a Driver
+func (g *group) Driver(driver Driver) Formatter {
+	g.driver = driver
+	return g
+}
+
+// Jumper sets a logical operator, for example " AND ", " OR "
+func (g *group) Jumper(jumper string) Formatter {
+	g.jumper = jumper
+	return g
+}
+
+func (g *group) setStringifier(s *Stringifier) {
+	g.str = s
+}
+
+func (g *group) s() *Stringifier {
+	return g.str
+}
+
+func (g *group) d() Driver {
+	if g.driver == nil {
+		return defaultDriver()
+	}
+	return g.driver
+}
+
+// print writes conditions or the neutral element if there are no conditions,
+// the group is nested in another formatter and is parenthesised if it has several terms
+func (g *group) print(p *printer) error {
+	var n, _, err = g.terms(p, true)
+	if err == nil && n == 0 {
+		p.buf = append(p.buf, g.neutral...)
+	}
+	return err
+}
+
+// top writes the group like print, but without parentheses as the whole query
+func (g *group) top(p *printer) error {
+	var n, _, err = g.terms(p, false)
+	if err == nil && n == 0 {
+		p.buf = append(p.buf, g.neutral...)
+	}
+	return err
+}
+
+// terms writes conditions and returns the number of written terms
+// and whether they are wrapped in parentheses,
+// it writes nothing if all conditions are empty, a nested group is written as its neutral element then
+// The group is parenthesised if it is nested and has several terms,
+// so is a nested formatter of several fragments joined by another jumper
+func (g *group) terms(p *printer, nested bool) (int, bool, error) {
+	var (
+		buf     = len(p.buf)
+		params  = len(p.params)
+		n       int
+		wrapped bool
+	)
+	if g.not {
+		p.buf = append(p.buf, "NOT ("...)
+	} else if nested {
+		p.buf = append(p.buf, '(')
+	}
+	var start = len(p.buf)
+	for _, c := range g.conditions {
+		if c == nil {
+			continue
+		}
+		var (
+			i = len(p.buf)
+			k = len(p.params)
+			m = 1
+			w bool
+		)
+		if n > 0 {
+			p.buf = append(p.buf, g.jumper...)
+		}
+		var j = len(p.buf)
+		var err error
+		switch x := c.(type) {
+		case *group:
+			// an empty nested group is its neutral element, so Or() still matches nothing
+			if m, w, err = x.terms(p, true); err == nil && m == 0 {
+				p.buf = append(p.buf, x.neutral...)
+				m = 1
+			}
+		case *formatter:
+			if w = len(x.format) > 1 && x.jumper != g.jumper; w {
+				p.buf = append(p.buf, '(')
+				j = len(p.buf)
+			}
+			err = p.text(c)
+			if w && len(p.buf) > j {
+				p.buf = append(p.buf, ')')
+			}
+		default:
+			err = p.text(c)
+		}
+		if err != nil {
+			return 0, false, err
+		}
+		if len(p.buf) == j || m == 0 {
+			// an empty condition is dropped with its jumper
+			p.buf, p.params = p.buf[:i], p.params[:k]
+			continue
+		}
+		n++
+		wrapped = w
+	}
+	switch {
+	case n == 0:
+		p.buf, p.params = p.buf[:buf], p.params[:params]
+		return 0, false, nil
+	case g.not && n == 1 && wrapped:
+		// a single term in parentheses needs no more
+		p.buf = append(p.buf[:start-1], p.buf[start:]...)
+		return n, false, nil
+	case g.not:
+		p.buf = append(p.buf, ')')
+		return n, false, nil
+	case nested && n == 1:
+		// a single term needs no parentheses
+		p.buf = append(p.buf[:start-1], p.buf[start:]...)
+		return n, wrapped, nil
+	case nested:
+		p.buf = append(p.buf, ')')
+		return n, true, nil
+	}
+	return n, n == 1 && wrapped, nil
+}
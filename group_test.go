@@ -0,0 +1,119 @@
+package qp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroup(t *testing.T) {
+	var cases = []struct {
+		name   string
+		query  Formatter
+		string string
+		params []interface{}
+	}{
+		{
+			name:   "case_nested",
+			query:  And(Format("a = %p", 1), Or(Format("b = %p", 2), Format("c = %p", 3))),
+			string: "a = $1 AND (b = $2 OR c = $3)",
+			params: []interface{}{1, 2, 3},
+		},
+		{
+			name:   "case_not",
+			query:  Or(Not(Format("a = %p", 1), Format("b = %p", 2)), Not(Format("c = %p", 3))),
+			string: "NOT (a = $1 AND b = $2) OR NOT (c = $3)",
+			params: []interface{}{1, 2, 3},
+		},
+		{
+			name:   "case_empty_children",
+			query:  And(nil, New(), Format("a = %p", 1), Or(), Or(New(), And()), Not(), Format("")),
+			string: "a = $1 AND 1=0 AND 1=1 AND 1=1",
+			params: []interface{}{1},
+		},
+		{
+			name:   "case_single_nested",
+			query:  And(Format("a = %p", 1), Or(nil, Format("b = %p", 2))),
+			string: "a = $1 AND b = $2",
+			params: []interface{}{1, 2},
+		},
+		{
+			name:   "case_empty_or",
+			query:  And(Format("tenant = %p", 1), Or()),
+			string: "tenant = $1 AND 1=0",
+			params: []interface{}{1},
+		},
+		{
+			name:   "case_and_neutral",
+			query:  And(New()),
+			string: "1=1",
+		},
+		{
+			name:   "case_or_neutral",
+			query:  Or(),
+			string: "1=0",
+		},
+		{
+			name:   "case_not_neutral",
+			query:  Not(And()),
+			string: "NOT (1=1)",
+		},
+		{
+			name:   "case_builder",
+			query:  And().Format("a = %p", 1).Format("b IN (%p)", []int{2, 3}),
+			string: "a = $1 AND b IN ($2, $3)",
+			params: []interface{}{1, 2, 3},
+		},
+		{
+			name:   "case_format",
+			query:  Format("SELECT id FROM users WHERE %s LIMIT %p", Or(Format("a = %p", 1), Format("b = %p", 2)), 10),
+			string: "SELECT id FROM users WHERE (a = $1 OR b = $2) LIMIT $3",
+			params: []interface{}{1, 2, 10},
+		},
+		{
+			name:   "case_format_not",
+			query:  Format("SELECT id FROM users WHERE %s", Not(Or(Format("a = %p", 1), Format("b = %p", 2)))),
+			string: "SELECT id FROM users WHERE NOT (a = $1 OR b = $2)",
+			params: []interface{}{1, 2},
+		},
+		{
+			name:   "case_not_nested",
+			query:  Not(Or(Format("a = %p", 1), Format("b = %p", 2))),
+			string: "NOT (a = $1 OR b = $2)",
+			params: []interface{}{1, 2},
+		},
+		{
+			name:   "case_formatter_jumper",
+			query:  And(Format("a = 1"), New().Jumper(" OR ").Format("b = 2").Format("c = 3")),
+			string: "a = 1 AND (b = 2 OR c = 3)",
+		},
+		{
+			name:   "case_formatter_same_jumper",
+			query:  Or(Format("a = 1"), New().Jumper(" OR ").Format("b = 2").Format("c = 3")),
+			string: "a = 1 OR b = 2 OR c = 3",
+		},
+		{
+			name:   "case_driver",
+			query:  And(Format("a = %p", 1), Or(Format("b = %p", 2), Format("c = %p", 3))).Driver(SqlserverDriver()),
+			string: "a = @p1 AND (b = @p2 OR c = @p3)",
+			params: []interface{}{1, 2, 3},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s, params, err := c.query.Build()
+			assert.NoError(t, err)
+			assert.Equal(t, c.string, s)
+			assert.Equal(t, c.params, params)
+		})
+	}
+}
+
+func TestGroup_Error(t *testing.T) {
+	_, _, err := And(Format("a = %p", 1), Or(Format("b = %p"))).Build()
+	assert.True(t, errors.Is(err, ErrParamNotFound))
+	assert.Panics(t, func() {
+		_ = Not(Format("a = %p")).String()
+	})
+}
@@ -0,0 +1,36 @@
+package qp
+
+// in is a membership condition which is valid for an empty list
+type in struct {
+	column interface{}
+	values interface{}
+	not    bool
+}
+
+// In returns a condition "column IN (values)", "1=0" if there are no values
+// A string column is quoted as an identifier, a Formatter column is written as is
+//		var query = qp.Format("SELECT name FROM users WHERE %s", qp.In("id", []int{1, 2}))
+//		_ = query.String() // SELECT name FROM users WHERE "id" IN ($1, $2)
+//		_ = qp.In("id", []int{}).String() // 1=0
+func In(column interface{}, values interface{}) Formatter {
+	return Format("%s", &in{column: column, values: values})
+}
+
+// NotIn returns a condition "column NOT IN (values)", "1=1" if there are no values
+func NotIn(column interface{}, values interface{}) Formatter {
+	return Format("%s", &in{column: column, values: values, not: true})
+}
+
+func (x *in) print(p *printer) error {
+	switch {
+	case x.values != nil && count(x.values) > 0 && x.not:
+		return p.text(Format("%i NOT IN (%p)", x.column, x.values))
+	case x.values != nil && count(x.values) > 0:
+		return p.text(Format("%i IN (%p)", x.column, x.values))
+	case x.not:
+		p.buf = append(p.buf, "1=1"...)
+	default:
+		p.buf = append(p.buf, "1=0"...)
+	}
+	return nil
+}
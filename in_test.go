@@ -0,0 +1,73 @@
+package qp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIn(t *testing.T) {
+	var cases = []struct {
+		name   string
+		query  Formatter
+		string string
+		params []interface{}
+	}{
+		{
+			name:   "case_ints",
+			query:  In("id", []int{1, 2}),
+			string: `"id" IN ($1, $2)`,
+			params: []interface{}{1, 2},
+		},
+		{
+			name:   "case_strings",
+			query:  NotIn("u.name", []string{"Tom"}),
+			string: `"u"."name" NOT IN ($1)`,
+			params: []interface{}{"Tom"},
+		},
+		{
+			name:   "case_expression",
+			query:  In(Format("lower(name)"), []interface{}{"tom", "huck"}),
+			string: `lower(name) IN ($1, $2)`,
+			params: []interface{}{"tom", "huck"},
+		},
+		{
+			name:   "case_empty",
+			query:  In("id", []int{}),
+			string: `1=0`,
+			params: []interface{}{},
+		},
+		{
+			name:   "case_not_empty",
+			query:  NotIn("id", []string(nil)),
+			string: `1=1`,
+			params: []interface{}{},
+		},
+		{
+			name:   "case_nil",
+			query:  In("id", nil),
+			string: `1=0`,
+			params: []interface{}{},
+		},
+		{
+			name:   "case_filter",
+			query:  Format("SELECT id FROM users WHERE %s AND age > %p", And(In("id", []int64{}), In("age", []int{12})), 10),
+			string: `SELECT id FROM users WHERE (1=0 AND "age" IN ($1)) AND age > $2`,
+			params: []interface{}{12, 10},
+		},
+		{
+			name:   "case_driver",
+			query:  In("id", []int{1, 2}).Driver(MysqlDriver()),
+			string: "`id` IN (?, ?)",
+			params: []interface{}{1, 2},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s, params, err := c.query.Build()
+			assert.NoError(t, err)
+			assert.Equal(t, c.string, s)
+			assert.Equal(t, c.params, params)
+		})
+	}
+}
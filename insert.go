@@ -0,0 +1,156 @@
+package qp
+
+import "reflect"
+
+// InsertBuilder builds an INSERT statement from structs
+type InsertBuilder struct {
+	table     string
+	skip      map[string]bool
+	upsert    bool
+	conflict  []string
+	update    []string
+	updateAll bool
+}
+
+// failed is a value which fails to print with an error
+type failed struct {
+	err error
+}
+
+func (x *failed) print(*printer) error {
+	return x.err
+}
+
+// Insert returns a builder of an INSERT statement into the table
+//		type User struct {
+//			ID   int64  `db:"id,omitempty"`
+//			Name string `db:"name"`
+//			Age  int    `db:"age"`
+//		}
+//
+//		var query = qp.Insert("users").Rows([]User{{Name: "Tom", Age: 12}, {Name: "Huckleberry", Age: 13}})
+//		_ = query.String() // INSERT INTO "users" ("name", "age") VALUES ($1, $2), ($3, $4)
+//		_ = query.Params() // ["Tom", 12, "Huckleberry", 13]
+func Insert(table string) *InsertBuilder {
+	return &InsertBuilder{
+		table: table,
+		skip:  map[string]bool{},
+	}
+}
+
+// Skip skips columns filled by the database, for example auto increment or default columns
+func (b *InsertBuilder) Skip(columns ...string) *InsertBuilder {
+	for _, c := range columns {
+		b.skip[c] = true
+	}
+	return b
+}
+
+// Rows returns a Formatter of the INSERT statement with rows
+// Rows is a struct or a slice of structs of the same type, pointers are allowed
+// Columns are taken from exported fields with "db" tags, see FormatNamed,
+// a column with the "omitempty" option is skipped if it is empty in every row
+func (b *InsertBuilder) Rows(rows interface{}) Formatter {
+	var columns, values, err = b.values(rows)
+	if err != nil {
+		return Format("%s", &failed{err: err})
+	}
+	var v = New().Jumper(", ").(*formatter)
+	for _, r := range values {
+		v.bind(insertRow, r)
+	}
+	return b.statement(columns, v)
+}
+
+// Batches returns INSERT statements with rows like Rows does,
+// but splits rows so every statement is under the parameter limit of the driver, see Batch
+func (b *InsertBuilder) Batches(d Driver, rows interface{}) ([]Formatter, error) {
+	var columns, values, err = b.values(rows)
+	if err != nil {
+		return nil, err
+	}
+	return batch(d, insertRow, values, func(v Formatter) Formatter {
+		return b.statement(columns, v)
+	})
+}
+
+// insertRow is a template of a row of values
+var insertRow = compile("(%+p)")
+
+// statement returns the INSERT statement with values
+func (b *InsertBuilder) statement(columns []string, values Formatter) Formatter {
+	if b.upsert {
+		return b.upsertOf(columns, values)
+	}
+	return Format("INSERT INTO %i (%i) VALUES %s", b.table, columns, values)
+}
+
+// values returns columns and values of rows
+func (b *InsertBuilder) values(rows interface{}) ([]string, [][]interface{}, error) {
+	var records, err = structsOf(rows)
+	if err == nil && len(records) == 0 {
+		err = ErrNoRows
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var fields = make([][]field, len(records))
+	for i, r := range records {
+		fields[i] = fieldsOf(r)
+	}
+
+	var (
+		columns = make([]string, 0, len(fields[0]))
+		indexes = make([]int, 0, len(fields[0]))
+	)
+	for i, f := range fields[0] {
+		if b.skip[f.name] || (f.omitempty && empty(fields, i)) {
+			continue
+		}
+		columns = append(columns, f.name)
+		indexes = append(indexes, i)
+	}
+
+	var values = make([][]interface{}, len(fields))
+	for n, f := range fields {
+		values[n] = make([]interface{}, len(indexes))
+		for k, i := range indexes {
+			values[n][k] = scalar{f[i].value.Interface()}
+		}
+	}
+	return columns, values, nil
+}
+
+// structsOf returns structs of a struct or a slice of structs of the same type
+func structsOf(x interface{}) ([]reflect.Value, error) {
+	var v = reflect.Indirect(reflect.ValueOf(x))
+	switch v.Kind() {
+	case reflect.Struct:
+		return []reflect.Value{v}, nil
+	case reflect.Slice, reflect.Array:
+		var structs = make([]reflect.Value, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			var e = v.Index(i)
+			for e.Kind() == reflect.Ptr || e.Kind() == reflect.Interface {
+				e = e.Elem()
+			}
+			if e.Kind() != reflect.Struct || (len(structs) > 0 && e.Type() != structs[0].Type()) {
+				return nil, unsupported(x)
+			}
+			structs = append(structs, e)
+		}
+		return structs, nil
+	}
+	return nil, unsupported(x)
+}
+
+// empty reports whether the field i is empty in every row
+func empty(rows [][]field, i int) bool {
+	for _, f := range rows {
+		if !f[i].value.IsZero() {
+			return false
+		}
+	}
+	return true
+}
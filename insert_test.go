@@ -0,0 +1,86 @@
+package qp
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testUser struct {
+	ID        int64     `db:"id,omitempty"`
+	Name      string    `db:"name"`
+	Age       int       `db:"age,omitempty"`
+	Tags      []string  `db:"tags"`
+	CreatedAt time.Time `db:"created_at"`
+	Comment   string    `db:"-"`
+}
+
+func TestInsert_EmbeddedPtr(t *testing.T) {
+	type Base struct {
+		ID int64 `db:"id"`
+	}
+	type User struct {
+		*Base
+		Name string `db:"name"`
+	}
+
+	q := Insert("users").Rows([]User{{Base: &Base{ID: 7}, Name: "Tom"}, {Name: "Huck"}})
+	assert.Equal(t,
+		`INSERT INTO "users" ("id", "name") VALUES ($1, $2), ($3, $4)`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{int64(7), "Tom", int64(0), "Huck"},
+		q.Params(),
+	)
+}
+
+func TestInsert_Rows(t *testing.T) {
+	q := Insert("users").Skip("created_at").Rows([]testUser{
+		{Name: "Tom", Tags: []string{"a", "b"}},
+		{Name: "Huckleberry", Age: 13},
+	})
+	assert.Equal(t,
+		`INSERT INTO "users" ("name", "age", "tags") VALUES ($1, $2, $3), ($4, $5, $6)`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{"Tom", 0, []string{"a", "b"}, "Huckleberry", 13, []string(nil)},
+		q.Params(),
+	)
+
+	q = Format("%s RETURNING id", Insert("public.users").Skip("tags", "created_at").Rows(&testUser{ID: 7, Name: "Tom"}))
+	assert.Equal(t,
+		`INSERT INTO "public"."users" ("id", "name") VALUES ($1, $2) RETURNING id`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{int64(7), "Tom"},
+		q.Params(),
+	)
+
+	q = Insert("users").Skip("tags", "created_at").Rows([]*testUser{{Name: "Tom"}, {Name: "Huck"}}).Driver(MysqlDriver())
+	assert.Equal(t,
+		"INSERT INTO `users` (`name`) VALUES (?), (?)",
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{"Tom", "Huck"},
+		q.Params(),
+	)
+}
+
+func TestInsert_Errors(t *testing.T) {
+	var err error
+
+	_, _, err = Insert("users").Rows([]testUser{}).Build()
+	assert.True(t, errors.Is(err, ErrNoRows))
+
+	_, _, err = Insert("users").Rows([]interface{}{testUser{}, 1}).Build()
+	assert.True(t, errors.Is(err, ErrUnsupportedValue))
+
+	_, _, err = Insert("users").Rows("users").Build()
+	assert.True(t, errors.Is(err, ErrUnsupportedValue))
+}
@@ -0,0 +1,90 @@
+package qp
+
+import "fmt"
+
+// interpolation holds options of Interpolate
+type interpolation struct {
+	redact func(i int, x interface{}) bool
+	mask   string
+}
+
+// InterpolateOption is an option of Interpolate
+type InterpolateOption func(*interpolation)
+
+// Redact masks parameters with the given indexes of Params
+func Redact(indexes ...int) InterpolateOption {
+	return RedactFunc(func(i int, _ interface{}) bool {
+		for _, n := range indexes {
+			if i == n {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// RedactFunc masks parameters for which fn returns true,
+// i is an index of the parameter in Params
+func RedactFunc(fn func(i int, x interface{}) bool) InterpolateOption {
+	return func(x *interpolation) {
+		var redact = x.redact
+		x.redact = func(i int, v interface{}) bool {
+			return (redact != nil && redact(i, v)) || fn(i, v)
+		}
+	}
+}
+
+// Interpolate returns a query string of the formatter
+// with every placeholder replaced by an escaped literal of the driver
+// The result is for logs and debugging only, it is prefixed by a comment and must not be executed
+//		var query = qp.Format("SELECT id FROM users WHERE name = %p AND password = %p", "Tom", "secret")
+//		_ = qp.Interpolate(query, qp.Redact(1)) // /* qp: interpolated, not for execution */ SELECT id FROM users WHERE name = 'Tom' AND password = '***'
+func Interpolate(f Formatter, opts ...InterpolateOption) string {
+	var p = printer{
+		driver: defaultDriver(),
+		debug:  &interpolation{mask: "'***'"},
+	}
+	for _, opt := range opts {
+		opt(p.debug)
+	}
+	if x, ok := f.(interface{ d() Driver }); ok {
+		p.driver = x.d()
+	}
+	if x, ok := f.(interface{ s() *Stringifier }); ok {
+		p.str = x.s()
+	}
+	p.buf = append(p.buf, "/* qp: interpolated, not for execution */ "...)
+	var err error
+	if x, ok := f.(interface{ top(*printer) error }); ok {
+		err = x.top(&p)
+	} else {
+		err = p.text(f)
+	}
+	if err != nil {
+		return "/* " + err.Error() + " */"
+	}
+	return string(p.buf)
+}
+
+// interpolate writes literals of x instead of placeholders
+func (p *printer) interpolate(x interface{}) {
+	var n = len(p.params)
+	p.params = insert(p.params, x)
+	for i := n; i < len(p.params); i++ {
+		if i > n {
+			p.buf = append(p.buf, ',', ' ')
+		}
+		p.interpolateAt(i)
+	}
+}
+
+// interpolateAt writes a literal or the mask of the parameter with the index i
+func (p *printer) interpolateAt(i int) {
+	if p.debug.redact != nil && p.debug.redact(i, p.params[i]) {
+		p.buf = append(p.buf, p.debug.mask...)
+		return
+	}
+	if err := p.literal(p.params[i]); err != nil {
+		_ = p.literal(fmt.Sprint(p.params[i]))
+	}
+}
@@ -0,0 +1,62 @@
+package qp
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterpolate(t *testing.T) {
+	b := Format("name = %[1]p OR nick = %[1]p", "O'Brien").Format("id IN (%p)", []int{1, 2})
+	q := Format(
+		"SELECT %i FROM users WHERE %s AND active = %p LIMIT %p",
+		"id", b, true, 10,
+	)
+	assert.Equal(t,
+		`/* qp: interpolated, not for execution */ SELECT "id" FROM users WHERE name = 'O''Brien' OR nick = 'O''Brien' AND id IN (1, 2) AND active = TRUE LIMIT 10`,
+		Interpolate(q),
+	)
+	assert.Equal(t,
+		"/* qp: interpolated, not for execution */ SELECT `id` FROM users WHERE name = 'O\\'Brien' OR nick = 'O\\'Brien' AND id IN (1, 2) AND active = TRUE LIMIT 10",
+		Interpolate(q.Driver(MysqlDriver())),
+	)
+	assert.Equal(t,
+		"SELECT `id` FROM users WHERE name = ? OR nick = ? AND id IN (?, ?) AND active = ? LIMIT ?",
+		q.String(),
+	)
+}
+
+func TestInterpolate_Redact(t *testing.T) {
+	q := Format(
+		"UPDATE users SET password = %p, token = %p WHERE id IN (%p)",
+		"secret", "token", []int{1, 2},
+	)
+	assert.Equal(t,
+		`/* qp: interpolated, not for execution */ UPDATE users SET password = '***', token = '***' WHERE id IN (1, '***')`,
+		Interpolate(q, Redact(0, 3), RedactFunc(func(_ int, x interface{}) bool { return x == "token" })),
+	)
+	assert.Equal(t,
+		[]interface{}{"secret", "token", 1, 2},
+		q.Params(),
+	)
+}
+
+func TestInterpolate_RedactNamed(t *testing.T) {
+	q := Format("a = %p OR b = %p AND pw = %p", sql.Named("id", 1), sql.Named("id", 1), "secret").Driver(SqlserverDriver())
+	assert.Equal(t,
+		[]interface{}{sql.Named("id", 1), "secret"},
+		q.Params(),
+	)
+	assert.Equal(t,
+		`/* qp: interpolated, not for execution */ a = 1 OR b = 1 AND pw = '***'`,
+		Interpolate(q, Redact(1)),
+	)
+}
+
+func TestInterpolate_Error(t *testing.T) {
+	assert.Equal(t,
+		`/* qp: parameter not found (fragment 0, offset 5, verb %p) */`,
+		Interpolate(Format("id = %p")),
+	)
+}
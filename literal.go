@@ -0,0 +1,109 @@
+package qp
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// literal encodes values as sql literals of a dialect
+type literal struct {
+	string func(s string) string
+	bytes  func(b []byte) string
+	time   func(t time.Time) string
+	bools  [2]string
+}
+
+// ansiLiteral is used by drivers without the LiteralEncoder interface
+var ansiLiteral = literal{
+	string: func(s string) string {
+		return quote(s, '\'', '\'')
+	},
+	bytes: func(b []byte) string {
+		return "X'" + hex.EncodeToString(b) + "'"
+	},
+	time: func(t time.Time) string {
+		return "TIMESTAMP '" + t.Format("2006-01-02 15:04:05.999999") + "'"
+	},
+	bools: [2]string{"FALSE", "TRUE"},
+}
+
+// encode returns x as a sql literal
+// It returns ErrUnsupportedValue for values which have no literal,
+// for example NaN, maps or structs
+func (l *literal) encode(x interface{}) (string, error) {
+	switch x := x.(type) {
+	case nil:
+		return "NULL", nil
+	case string:
+		return l.string(x), nil
+	case []byte:
+		if x == nil {
+			return "NULL", nil
+		}
+		return l.bytes(x), nil
+	case bool:
+		return l.bools[btoi(x)], nil
+	case time.Time:
+		return l.time(x), nil
+	case fmt.Stringer:
+		return l.string(x.String()), nil
+	}
+
+	var v = reflect.ValueOf(x)
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return "NULL", nil
+		}
+		return l.encode(v.Elem().Interface())
+	case reflect.String:
+		return l.string(v.String()), nil
+	case reflect.Bool:
+		return l.bools[btoi(v.Bool())], nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		var f = v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			break
+		}
+		return strconv.FormatFloat(f, 'g', -1, v.Type().Bits()), nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return l.encode(v.Bytes())
+		}
+	}
+	return "", unsupported(x)
+}
+
+// escape a helper function escapes special characters of a string with a backslash
+// For example: escape("it's\n") => `it\'s\n`
+func escape(s string) string {
+	var b strings.Builder
+	b.Grow(len(s) + 2)
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case 0:
+			b.WriteString(`\0`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\x1a':
+			b.WriteString(`\Z`)
+		case '\\', '\'', '"':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
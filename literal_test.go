@@ -0,0 +1,139 @@
+package qp
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testStatus string
+
+func TestLiteral_Encode(t *testing.T) {
+	var (
+		name = "Tom"
+		nilp *string
+		date = time.Date(2020, 1, 24, 10, 30, 0, 500000000, time.UTC)
+	)
+	var testCases = []struct {
+		name   string
+		input  interface{}
+		output string
+	}{
+		{
+			name:   "case_nil",
+			input:  nil,
+			output: "NULL",
+		}, {
+			name:   "case_string",
+			input:  "it's",
+			output: "'it''s'",
+		}, {
+			name:   "case_named_string",
+			input:  testStatus("active"),
+			output: "'active'",
+		}, {
+			name:   "case_pointer",
+			input:  &name,
+			output: "'Tom'",
+		}, {
+			name:   "case_nil_pointer",
+			input:  nilp,
+			output: "NULL",
+		}, {
+			name:   "case_bool",
+			input:  true,
+			output: "TRUE",
+		}, {
+			name:   "case_int",
+			input:  -12,
+			output: "-12",
+		}, {
+			name:   "case_uint64",
+			input:  uint64(math.MaxUint64),
+			output: "18446744073709551615",
+		}, {
+			name:   "case_float",
+			input:  0.1234567,
+			output: "0.1234567",
+		}, {
+			name:   "case_bytes",
+			input:  []byte{0xde, 0xad},
+			output: "X'dead'",
+		}, {
+			name:   "case_nil_bytes",
+			input:  []byte(nil),
+			output: "NULL",
+		}, {
+			name:   "case_time",
+			input:  date,
+			output: "TIMESTAMP '2020-01-24 10:30:00.5'",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := ansiLiteral.encode(tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.output, output)
+		})
+	}
+
+	_, err := ansiLiteral.encode(math.NaN())
+	assert.True(t, errors.Is(err, ErrUnsupportedValue))
+
+	_, err = ansiLiteral.encode(struct{}{})
+	assert.True(t, errors.Is(err, ErrUnsupportedValue))
+}
+
+func TestLiteral_Drivers(t *testing.T) {
+	var (
+		date  = time.Date(2020, 1, 24, 10, 30, 0, 0, time.UTC)
+		input = []interface{}{"it's \\", true, []byte{0xbe, 0xef}, date}
+	)
+	var testCases = []struct {
+		name   string
+		driver Driver
+		output string
+	}{
+		{
+			name:   "case_postgres",
+			driver: PgsqlDriver(),
+			output: `'it''s \', TRUE, '\xbeef'::bytea, '2020-01-24 10:30:00+00:00'::timestamptz`,
+		}, {
+			name:   "case_mysql",
+			driver: MysqlDriver(),
+			output: `'it\'s \\', TRUE, X'beef', '2020-01-24 10:30:00'`,
+		}, {
+			name:   "case_sqlite",
+			driver: SqliteDriver(),
+			output: `'it''s \', 1, X'beef', '2020-01-24 10:30:00+00:00'`,
+		}, {
+			name:   "case_sqlserver",
+			driver: SqlserverDriver(),
+			output: `N'it''s \', 1, 0xbeef, '2020-01-24T10:30:00+00:00'`,
+		}, {
+			name:   "case_oracle",
+			driver: OracleDriver(),
+			output: `'it''s \', 1, HEXTORAW('beef'), TIMESTAMP '2020-01-24 10:30:00 +00:00'`,
+		}, {
+			name:   "case_default",
+			driver: testDriver{},
+			output: `'it''s \', TRUE, X'beef', TIMESTAMP '2020-01-24 10:30:00'`,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var p = printer{driver: tt.driver}
+			assert.NoError(t, p.literal(input))
+			assert.Equal(t, tt.output, string(p.buf))
+		})
+	}
+}
+
+func TestUtils_escape(t *testing.T) {
+	assert.Equal(t, `it\'s \"a\"\\\n\r\0\Z`, escape("it's \"a\"\\\n\r\x00\x1a"))
+}
@@ -0,0 +1,67 @@
+package qp
+
+import "reflect"
+
+// names holds parameters of a formatter bound by name
+type names map[string]interface{}
+
+// FormatNamed formats according to a format specifier with named verbs
+// and returns the sql query string
+// Parameters are taken from a map with string keys or a struct with "db" tags,
+// fields without a tag are named by the field name in lower case
+//		var query = qp.FormatNamed("SELECT id FROM table WHERE name = %{name}p OR nick = %{name}p", map[string]interface{}{"name": "Tom"})
+//		_ = query.String() // SELECT id FROM table WHERE name = $1 OR nick = $1
+//		_ = query.Params() // ["Tom"]
+func FormatNamed(format string, arg interface{}) Formatter {
+	return &formatter{
+		format: []*Template{compile(format)},
+		params: [][]interface{}{{toNames(arg)}},
+		jumper: " AND ",
+	}
+}
+
+// BindNamed returns a Formatter of the template with named parameters
+// Parameters are taken as in FormatNamed
+func (t *Template) BindNamed(arg interface{}) Formatter {
+	return &formatter{
+		format: []*Template{t},
+		params: [][]interface{}{{toNames(arg)}},
+		jumper: " AND ",
+	}
+}
+
+// namesOf returns named parameters of a fragment or nil
+func namesOf(params []interface{}) names {
+	if len(params) == 1 {
+		if x, ok := params[0].(names); ok {
+			return x
+		}
+	}
+	return nil
+}
+
+// toNames converts a map or a struct to named parameters
+func toNames(arg interface{}) names {
+	var x = names{}
+	switch arg := arg.(type) {
+	case map[string]interface{}:
+		for k, v := range arg {
+			x[k] = v
+		}
+		return x
+	}
+	var v = reflect.Indirect(reflect.ValueOf(arg))
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() == reflect.String {
+			for _, k := range v.MapKeys() {
+				x[k.String()] = v.MapIndex(k).Interface()
+			}
+		}
+	case reflect.Struct:
+		for _, f := range fieldsOf(v) {
+			x[f.name] = f.value.Interface()
+		}
+	}
+	return x
+}
@@ -0,0 +1,126 @@
+package qp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatNamed_Map(t *testing.T) {
+	q := FormatNamed(
+		"SELECT id FROM table WHERE (name = %{name}p OR nick = %{name}p) AND id IN (%{ids}+p) ORDER BY %{order}s",
+		map[string]interface{}{"name": "Tom", "ids": []int{1, 2}, "order": "id"},
+	)
+	assert.Equal(t,
+		`SELECT id FROM table WHERE (name = $1 OR nick = $1) AND id IN ($2, $3) ORDER BY id`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{"Tom", 1, 2},
+		q.Params(),
+	)
+
+	q.Driver(MysqlDriver())
+	assert.Equal(t,
+		`SELECT id FROM table WHERE (name = ? OR nick = ?) AND id IN (?, ?) ORDER BY id`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{"Tom", "Tom", 1, 2},
+		q.Params(),
+	)
+
+	// oracle binds every placeholder by position, a repeated name is bound again
+	q.Driver(OracleDriver())
+	assert.Equal(t,
+		`SELECT id FROM table WHERE (name = :1 OR nick = :2) AND id IN (:3, :4) ORDER BY id`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{"Tom", "Tom", 1, 2},
+		q.Params(),
+	)
+}
+
+func TestFormatNamed_Struct(t *testing.T) {
+	type Base struct {
+		ID int `db:"user_id"`
+	}
+	type User struct {
+		Base
+		Name    string
+		Age     int    `db:"age,omitempty"`
+		Comment string `db:"-"`
+		secret  string
+	}
+
+	q := FormatNamed(
+		"UPDATE users SET name = %{name}p, age = %{age}p WHERE id = %{user_id}p",
+		&User{Base: Base{ID: 7}, Name: "Tom", Age: 12, secret: "x"},
+	)
+	assert.Equal(t,
+		`UPDATE users SET name = $1, age = $2 WHERE id = $3`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{"Tom", 12, 7},
+		q.Params(),
+	)
+
+	_, _, err := FormatNamed("%{comment}p", User{}).Build()
+	assert.Equal(t, &FormatError{Offset: 0, Verb: 'p', Name: "comment", Err: ErrParamNotFound}, err)
+	assert.EqualError(t, err, "qp: parameter not found (fragment 0, offset 0, verb %p, name comment)")
+}
+
+func TestFormatNamed_EmbeddedPtr(t *testing.T) {
+	type Base struct {
+		ID int `db:"id"`
+	}
+	type User struct {
+		*Base
+		Name string
+	}
+
+	q := FormatNamed("UPDATE users SET name = %{name}p WHERE id = %{id}p", User{Base: &Base{ID: 7}, Name: "Tom"})
+	assert.Equal(t, `UPDATE users SET name = $1 WHERE id = $2`, q.String())
+	assert.Equal(t, []interface{}{"Tom", 7}, q.Params())
+
+	q = FormatNamed("id = %{id}p", User{Name: "Tom"})
+	assert.Equal(t, []interface{}{0}, q.Params())
+}
+
+func TestFormatNamed_Nested(t *testing.T) {
+	b := FormatNamed("name = %{name}p", map[string]string{"name": "Tom"}).
+		Format("age = %p", 12)
+	q := Format(
+		"SELECT id FROM table WHERE id = %p AND %s",
+		1, b,
+	)
+	assert.Equal(t,
+		`SELECT id FROM table WHERE id = $1 AND name = $2 AND age = $3`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{1, "Tom", 12},
+		q.Params(),
+	)
+
+	_, _, err := FormatNamed("name = %{name}p AND age = %p", map[string]string{"name": "Tom"}).Build()
+	assert.Equal(t, &FormatError{Offset: 26, Verb: 'p', Err: ErrParamNotFound}, err)
+
+	_, _, err = Format("name = %{name}p", "Tom").Build()
+	assert.Equal(t, &FormatError{Offset: 7, Verb: 'p', Name: "name", Err: ErrParamNotFound}, err)
+}
+
+func TestTemplate_BindNamed(t *testing.T) {
+	tpl := MustCompile("SELECT id FROM table WHERE name = %{name}p LIMIT %{limit}p")
+	q := tpl.BindNamed(map[string]interface{}{"name": "Tom", "limit": 10}).Driver(SqliteNumberedDriver())
+	assert.Equal(t,
+		`SELECT id FROM table WHERE name = ?1 LIMIT ?2`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{"Tom", 10},
+		q.Params(),
+	)
+}
@@ -0,0 +1,22 @@
+package qp
+
+// paging is a dialect specific paging clause
+type paging struct {
+	limit  interface{}
+	offset interface{}
+}
+
+// Paging returns a paging clause for the driver of the query
+//		var query = qp.Format("SELECT id FROM users ORDER BY id %s", qp.Paging(10, 20))
+//		_ = query.String() // SELECT id FROM users ORDER BY id LIMIT $1 OFFSET $2
+//		_ = query.Driver(qp.SqlserverDriver()).String() // SELECT id FROM users ORDER BY id OFFSET @p1 ROWS FETCH NEXT @p2 ROWS ONLY
+func Paging(limit, offset interface{}) Formatter {
+	return Format("%s", &paging{limit: limit, offset: offset})
+}
+
+func (x *paging) print(p *printer) error {
+	if d, ok := p.driver.(Pager); ok {
+		return p.text(d.Paging(x.limit, x.offset))
+	}
+	return p.text(Format("LIMIT %p OFFSET %p", x.limit, x.offset))
+}
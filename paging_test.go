@@ -0,0 +1,42 @@
+package qp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaging(t *testing.T) {
+	q := Format(
+		"SELECT id FROM table WHERE status = %p ORDER BY id %s",
+		"active", Paging(10, 20),
+	)
+	assert.Equal(t,
+		`SELECT id FROM table WHERE status = $1 ORDER BY id LIMIT $2 OFFSET $3`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{"active", 10, 20},
+		q.Params(),
+	)
+
+	q.Driver(MysqlDriver())
+	assert.Equal(t,
+		`SELECT id FROM table WHERE status = ? ORDER BY id LIMIT ? OFFSET ?`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{"active", 10, 20},
+		q.Params(),
+	)
+
+	q.Driver(SqlserverDriver())
+	assert.Equal(t,
+		`SELECT id FROM table WHERE status = @p1 ORDER BY id OFFSET @p2 ROWS FETCH NEXT @p3 ROWS ONLY`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{"active", 20, 10},
+		q.Params(),
+	)
+}
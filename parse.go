@@ -0,0 +1,112 @@
+package qp
+
+import (
+	"strconv"
+	"strings"
+)
+
+// token is a piece of a parsed format fragment:
+// a literal text followed by a verb
+type token struct {
+	text   string
+	verb   byte
+	spread bool
+	name   string
+	index  int
+	offset int
+	raw    string // the verb as it is in the format, written if the verb is not registered
+}
+
+// parse splits a format fragment into tokens, a verb is any ASCII letter, see RegisterVerb
+// The last token always holds a trailing text and has no verb
+//		"id = %p AND %%s" => [{text: "id = ", verb: 'p', raw: "%p"}, {text: " AND %s"}]
+//		"id = %{id}p" => [{text: "id = ", verb: 'p', name: "id", raw: "%{id}p"}, {text: ""}]
+//		"id = %[2]p" => [{text: "id = ", verb: 'p', index: 2, raw: "%[2]p"}, {text: ""}]
+func parse(format string) []token {
+	var (
+		tokens = make([]token, 0, strings.Count(format, "%")+1)
+		text   []byte // text of the current token if it has escaped percent signs
+		j      int
+	)
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		var (
+			k      = plus(format, i+1)
+			spread = k > i+1
+			name   string
+			index  int
+		)
+		if k < len(format) && format[k] == '{' {
+			if e := strings.IndexByte(format[k:], '}'); e > 1 {
+				var m = plus(format, k+e+1)
+				name = format[k+1 : k+e]
+				spread = spread || m > k+e+1
+				k = m
+			}
+		} else if k < len(format) && format[k] == '[' {
+			if e := strings.IndexByte(format[k:], ']'); e > 1 && isDigit(format[k+1]) {
+				if x, err := strconv.Atoi(format[k+1 : k+e]); err == nil && x > 0 {
+					var m = plus(format, k+e+1)
+					index = x
+					spread = spread || m > k+e+1
+					k = m
+				}
+			}
+		}
+		if k == len(format) {
+			break
+		}
+		switch {
+		case format[k] == '%':
+			// "%%" is an escaped percent sign, "%+%" is left as is
+			if k == i+1 {
+				text = append(text, format[j:k]...)
+				j = k + 1
+			}
+		case isLetter(format[k]):
+			tokens = append(tokens, token{
+				text:   cut(format, j, i, &text),
+				verb:   format[k],
+				spread: spread,
+				name:   name,
+				index:  index,
+				offset: i,
+				raw:    format[i : k+1],
+			})
+			j = k + 1
+		}
+		i = k
+	}
+	return append(tokens, token{text: cut(format, j, len(format), &text)})
+}
+
+// cut returns format[j:i] appended to text, it is a substring of the format if text is empty
+// text is reset for the next token
+func cut(format string, j, i int, text *[]byte) string {
+	if len(*text) == 0 {
+		return format[j:i]
+	}
+	var s = string(append(*text, format[j:i]...))
+	*text = (*text)[:0]
+	return s
+}
+
+// plus returns an index of the first byte after '+' modifiers
+func plus(format string, i int) int {
+	for i < len(format) && format[i] == '+' {
+		i++
+	}
+	return i
+}
+
+// isLetter reports whether c is an ASCII letter
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// isDigit reports whether c is a decimal digit
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
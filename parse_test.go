@@ -0,0 +1,75 @@
+package qp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	var testCases = []struct {
+		name   string
+		input  string
+		output []token
+	}{
+		{
+			name:   "case_text",
+			input:  "SELECT 1",
+			output: []token{{text: "SELECT 1"}},
+		}, {
+			name:  "case_verbs",
+			input: "id = %p AND name IN (%+s)",
+			output: []token{
+				{text: "id = ", verb: 'p', offset: 5, raw: "%p"},
+				{text: " AND name IN (", verb: 's', spread: true, offset: 21, raw: "%+s"},
+				{text: ")"},
+			},
+		}, {
+			name:  "case_escape",
+			input: "%%s%s",
+			output: []token{
+				{text: "%s", verb: 's', offset: 3, raw: "%s"},
+				{text: ""},
+			},
+		}, {
+			name:  "case_named",
+			input: "id = %{id}p AND name IN (%{names}+s) OR %+{x}p",
+			output: []token{
+				{text: "id = ", verb: 'p', name: "id", offset: 5, raw: "%{id}p"},
+				{text: " AND name IN (", verb: 's', spread: true, name: "names", offset: 25, raw: "%{names}+s"},
+				{text: ") OR ", verb: 'p', spread: true, name: "x", offset: 40, raw: "%+{x}p"},
+				{text: ""},
+			},
+		}, {
+			name:   "case_named_invalid",
+			input:  "%{}p, %{x, %{x}%",
+			output: []token{{text: "%{}p, %{x, %{x}%"}},
+		}, {
+			name:  "case_index",
+			input: "%[2]p, %[1]+s, %+[3]p",
+			output: []token{
+				{text: "", verb: 'p', index: 2, offset: 0, raw: "%[2]p"},
+				{text: ", ", verb: 's', spread: true, index: 1, offset: 7, raw: "%[1]+s"},
+				{text: ", ", verb: 'p', spread: true, index: 3, offset: 15, raw: "%+[3]p"},
+				{text: ""},
+			},
+		}, {
+			name:   "case_index_invalid",
+			input:  "%[0]p, %[-1]p, %[x]p, %[]p, %[1",
+			output: []token{{text: "%[0]p, %[-1]p, %[x]p, %[]p, %[1"}},
+		}, {
+			name:  "case_letters",
+			input: "%d, %+%s, %+",
+			output: []token{
+				{text: "", verb: 'd', offset: 0, raw: "%d"},
+				{text: ", %+%s, %+"},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.output, parse(tt.input))
+		})
+	}
+}
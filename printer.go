@@ -0,0 +1,344 @@
+package qp
+
+import (
+	"database/sql"
+	sqldriver "database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// printer renders a Formatter tree into a query string and parameters in a single pass
+// Nested formatters are printed into the same printer,
+// so the query string and parameters are always in sync
+// The printer holds all state of a render, including placeholder numbering
+type printer struct {
+	buf    []byte
+	params []interface{}
+	driver Driver
+	str    *Stringifier   // nil means a Stringifier of the driver, see stringifier
+	debug  *interpolation // literals are written instead of placeholders, see Interpolate
+	names  map[string]int // indexes of bound sql.NamedArg in params by names
+}
+
+// printable is implemented by values that print themselves,
+// for example formatters of the package
+type printable interface {
+	print(p *printer) error
+}
+
+// placeholder writes placeholders for x and appends x to parameters
+// A sql.NamedArg is bound by name if the driver supports it, a repeated name is bound once
+// and must have the same value
+func (p *printer) placeholder(x interface{}) error {
+	if a, ok := x.(sql.NamedArg); ok {
+		if d, ok := p.driver.(NamedPlaceholder); ok && a.Name != "" {
+			var i, bound = p.names[a.Name]
+			if bound && !reflect.DeepEqual(p.params[i].(sql.NamedArg).Value, a.Value) {
+				return fmt.Errorf("%w '%s'", ErrNamedArgConflict, a.Name)
+			}
+			if !bound {
+				if p.names == nil {
+					p.names = map[string]int{}
+				}
+				i = len(p.params)
+				p.names[a.Name] = i
+				p.params = append(p.params, a)
+			}
+			if p.debug != nil {
+				// the literal of the bound parameter, so indexes of Redact are indexes of Params
+				p.interpolateAt(i)
+				return nil
+			}
+			p.buf = append(p.buf, d.NamedPlaceholder(a.Name)...)
+			return nil
+		}
+	}
+	if p.debug != nil {
+		p.interpolate(x)
+		return nil
+	}
+	switch d := p.driver.(type) {
+	case placeholderAppender:
+		p.buf = d.appendPlaceholder(p.buf, len(p.params), x)
+	case Positional:
+		p.buf = append(p.buf, d.PlaceholderAt(len(p.params), x)...)
+	default:
+		p.buf = append(p.buf, d.Placeholder(x)...)
+	}
+	p.params = insert(p.params, x)
+	return nil
+}
+
+// placeholderAppender is implemented by drivers of the package,
+// they append placeholders to the query instead of returning a string for every placeholder
+type placeholderAppender interface {
+	appendPlaceholder(b []byte, n int, x interface{}) []byte
+}
+
+// convert applies the ParamConverter of the driver to every parameter
+func (p *printer) convert() error {
+	var d, ok = p.driver.(ParamConverter)
+	if !ok {
+		return nil
+	}
+	for i, x := range p.params {
+		var err error
+		if a, ok := x.(sql.NamedArg); ok {
+			a.Value, err = d.ConvertParam(a.Value)
+			x = a
+		} else {
+			x, err = d.ConvertParam(x)
+		}
+		if err != nil {
+			return fmt.Errorf("qp: %w (parameter %d)", err, i+1)
+		}
+		p.params[i] = x
+	}
+	return nil
+}
+
+// numbered reports whether the driver numbers placeholders
+func (p *printer) numbered() bool {
+	d, ok := p.driver.(Numbered)
+	return ok && d.Numbered()
+}
+
+// ident writes x as a quoted identifier
+// A nested Formatter is written as is
+//		"public.users" => "public"."users"
+//		[]string{"id", "t.*"} => "id", "t".*
+func (p *printer) ident(x interface{}) error {
+	switch x := x.(type) {
+	case string:
+		p.quote(x)
+	case []string:
+		for i := range x {
+			if i > 0 {
+				p.buf = append(p.buf, ',', ' ')
+			}
+			p.quote(x[i])
+		}
+	case []interface{}:
+		for i := range x {
+			if i > 0 {
+				p.buf = append(p.buf, ',', ' ')
+			}
+			if err := p.ident(x[i]); err != nil {
+				return err
+			}
+		}
+	case printable, Formatter:
+		return p.text(x)
+	default:
+		if xs, ok := expand(x); ok {
+			return p.ident(xs)
+		}
+		var s = printer{driver: p.driver, str: p.str}
+		if err := s.text(x); err != nil {
+			return err
+		}
+		p.quote(string(s.buf))
+	}
+	return nil
+}
+
+// quote writes a quoted identifier, a qualified name is quoted by parts, "*" is not quoted
+func (p *printer) quote(x string) {
+	for i := 0; ; i++ {
+		var (
+			name = x
+			e    = strings.IndexByte(x, '.')
+		)
+		if e >= 0 {
+			name, x = x[:e], x[e+1:]
+		}
+		if i > 0 {
+			p.buf = append(p.buf, '.')
+		}
+		if name == "*" {
+			p.buf = append(p.buf, name...)
+		} else if d, ok := p.driver.(Quoter); ok {
+			p.buf = append(p.buf, d.QuoteIdent(name)...)
+		} else {
+			p.buf = append(p.buf, quote(name, '"', '"')...)
+		}
+		if e < 0 {
+			return
+		}
+	}
+}
+
+// literal writes x as an escaped sql literal of the driver
+// A nested Formatter is written as is
+//		[]interface{}{"it's", nil, true} => 'it''s', NULL, TRUE
+func (p *printer) literal(x interface{}) error {
+	switch x := x.(type) {
+	case []int:
+		p.buf = append(p.buf, intsToString(x)...)
+	case []int64:
+		p.buf = append(p.buf, int64sToString(x)...)
+	case []string:
+		for i := range x {
+			if i > 0 {
+				p.buf = append(p.buf, ',', ' ')
+			}
+			if err := p.literal(x[i]); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i := range x {
+			if i > 0 {
+				p.buf = append(p.buf, ',', ' ')
+			}
+			if err := p.literal(x[i]); err != nil {
+				return err
+			}
+		}
+	case printable, Formatter:
+		return p.text(x)
+	case sql.NamedArg:
+		return p.literal(x.Value)
+	case sqldriver.Valuer:
+		v, err := value(x)
+		if err != nil {
+			return err
+		}
+		return p.literal(v)
+	default:
+		if xs, ok := expand(x); ok {
+			return p.literal(xs)
+		}
+		var (
+			s   string
+			err error
+		)
+		if d, ok := p.driver.(LiteralEncoder); ok {
+			s, err = d.EncodeLiteral(x)
+		} else {
+			s, err = ansiLiteral.encode(x)
+		}
+		if err != nil {
+			return err
+		}
+		p.buf = append(p.buf, s...)
+	}
+	return nil
+}
+
+// text writes x as a string
+// A nested Formatter writes its own query string and parameters, a foreign one only its text,
+// nil, bools, floats, times and hooked types are written by the Stringifier
+func (p *printer) text(x interface{}) error {
+	var s = p.stringifier()
+	if h, ok := s.hook(x); ok {
+		p.buf = append(p.buf, h(x)...)
+		return nil
+	}
+	switch x := x.(type) {
+	case string:
+		p.buf = append(p.buf, x...)
+	case printable:
+		if n, ok := x.(interface{ s() *Stringifier }); ok && n.s() != nil {
+			// a nested formatter with its own Stringifier renders with it
+			var str = p.str
+			p.str = n.s()
+			var err = x.print(p)
+			p.str = str
+			return err
+		}
+		return x.print(p)
+	case Formatter:
+		// a foreign formatter renders with its own driver and numbering,
+		// so only its text without parameters is safe
+		s, params, err := x.Build()
+		if err != nil {
+			return err
+		}
+		if len(params) > 0 {
+			return ErrForeignFormatter
+		}
+		p.buf = append(p.buf, s...)
+	case Expander:
+		if xs, ok := x.Expand(); ok {
+			return p.text(xs)
+		}
+		p.buf = append(p.buf, fmt.Sprint(x)...)
+	case sql.NamedArg:
+		return p.text(x.Value)
+	case sqldriver.Valuer:
+		v, err := value(x)
+		if err != nil {
+			return err
+		}
+		return p.text(v)
+	case time.Time:
+		p.buf = append(p.buf, s.time(x)...)
+	case fmt.Stringer:
+		p.buf = append(p.buf, x.String()...)
+	case int:
+		p.buf = strconv.AppendInt(p.buf, int64(x), 10)
+	case int8:
+		p.buf = strconv.AppendInt(p.buf, int64(x), 10)
+	case int16:
+		p.buf = strconv.AppendInt(p.buf, int64(x), 10)
+	case int32:
+		p.buf = strconv.AppendInt(p.buf, int64(x), 10)
+	case int64:
+		p.buf = strconv.AppendInt(p.buf, x, 10)
+	case uint:
+		p.buf = strconv.AppendUint(p.buf, uint64(x), 10)
+	case uint8:
+		p.buf = strconv.AppendUint(p.buf, uint64(x), 10)
+	case uint16:
+		p.buf = strconv.AppendUint(p.buf, uint64(x), 10)
+	case uint32:
+		p.buf = strconv.AppendUint(p.buf, uint64(x), 10)
+	case uint64:
+		p.buf = strconv.AppendUint(p.buf, x, 10)
+	case float32:
+		p.buf = append(p.buf, s.float(float64(x), 32)...)
+	case float64:
+		p.buf = append(p.buf, s.float(x, 64)...)
+	case bool:
+		p.buf = append(p.buf, s.bools(x)...)
+	case []byte:
+		p.buf = append(p.buf, x...)
+	case []rune:
+		p.buf = append(p.buf, string(x)...)
+	case []int:
+		p.buf = append(p.buf, intsToString(x)...)
+	case []int64:
+		p.buf = append(p.buf, int64sToString(x)...)
+	case []string:
+		p.buf = append(p.buf, stringsToString(x)...)
+	case []interface{}:
+		for i := range x {
+			if i > 0 {
+				p.buf = append(p.buf, ',', ' ')
+			}
+			if err := p.text(x[i]); err != nil {
+				return err
+			}
+		}
+	case nil:
+		p.buf = append(p.buf, s.Null...)
+	default:
+		if xs, ok := expand(x); ok {
+			return p.text(xs)
+		}
+		p.buf = append(p.buf, fmt.Sprint(x)...)
+	}
+	return nil
+}
+
+// value returns the value of a driver.Valuer, a nil pointer is NULL
+func value(x sqldriver.Valuer) (sqldriver.Value, error) {
+	if v := reflect.ValueOf(x); v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil, nil
+	}
+	return x.Value()
+}
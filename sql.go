@@ -0,0 +1,78 @@
+package qp
+
+import (
+	"context"
+	"database/sql"
+)
+
+type (
+	// Querier is implemented by *sql.DB, *sql.Tx and *sql.Conn
+	Querier interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+		QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	}
+
+	// Row is the result of QueryRow
+	Row struct {
+		row *sql.Row
+		err error
+	}
+)
+
+var (
+	_ Querier = (*sql.DB)(nil)
+	_ Querier = (*sql.Tx)(nil)
+	_ Querier = (*sql.Conn)(nil)
+)
+
+// Exec builds the formatter once and executes the query without returning any rows
+//		var _, err = qp.Exec(ctx, db, qp.Format("DELETE FROM users WHERE id = %p", 1))
+func Exec(ctx context.Context, db Querier, f Formatter) (sql.Result, error) {
+	query, params, err := f.Build()
+	if err != nil {
+		return nil, err
+	}
+	return db.ExecContext(ctx, query, params...)
+}
+
+// Query builds the formatter once and executes the query that returns rows
+//		var rows, err = qp.Query(ctx, db, qp.Format("SELECT id FROM users WHERE name = %p", "Tom"))
+func Query(ctx context.Context, db Querier, f Formatter) (*sql.Rows, error) {
+	query, params, err := f.Build()
+	if err != nil {
+		return nil, err
+	}
+	return db.QueryContext(ctx, query, params...)
+}
+
+// QueryRow builds the formatter once and executes the query that returns at most one row
+// An error of the build is deferred until Scan
+//		var id int64
+//		var err = qp.QueryRow(ctx, db, qp.Format("SELECT id FROM users WHERE name = %p", "Tom")).Scan(&id)
+func QueryRow(ctx context.Context, db Querier, f Formatter) *Row {
+	query, params, err := f.Build()
+	if err != nil {
+		return &Row{err: err}
+	}
+	return &Row{row: db.QueryRowContext(ctx, query, params...)}
+}
+
+// Scan copies the columns of the row into dest, see sql.Row.Scan
+func (r *Row) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.row.Scan(dest...)
+}
+
+// ExecAll executes formatters one by one, for example batches of Batch
+// Pass a *sql.Tx to execute them in one transaction
+func ExecAll(ctx context.Context, db Querier, fs []Formatter) error {
+	for _, f := range fs {
+		if _, err := Exec(ctx, db, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
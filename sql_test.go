@@ -0,0 +1,170 @@
+package qp
+
+import (
+	"context"
+	"database/sql"
+	sqldriver "database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testSQL is a fake database/sql driver which records executed queries
+type testSQL struct {
+	mu      sync.Mutex
+	queries []string
+	params  [][]sqldriver.Value
+}
+
+type (
+	testConn struct{ d *testSQL }
+	testStmt struct {
+		d     *testSQL
+		query string
+	}
+	testRows struct{ n int }
+)
+
+var testDB = new(testSQL)
+
+func init() {
+	sql.Register("qp_test", testDB)
+}
+
+func (d *testSQL) Open(string) (sqldriver.Conn, error) { return &testConn{d: d}, nil }
+
+func (d *testSQL) last() (string, []sqldriver.Value) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.queries[len(d.queries)-1], d.params[len(d.params)-1]
+}
+
+func (c *testConn) Prepare(query string) (sqldriver.Stmt, error) {
+	return &testStmt{d: c.d, query: query}, nil
+}
+func (c *testConn) Close() error                 { return nil }
+func (c *testConn) Begin() (sqldriver.Tx, error) { return c, nil }
+func (c *testConn) Commit() error                { return nil }
+func (c *testConn) Rollback() error              { return nil }
+
+func (s *testStmt) Close() error  { return nil }
+func (s *testStmt) NumInput() int { return -1 }
+
+func (s *testStmt) Exec(args []sqldriver.Value) (sqldriver.Result, error) {
+	s.record(args)
+	return sqldriver.RowsAffected(len(args)), nil
+}
+
+func (s *testStmt) Query(args []sqldriver.Value) (sqldriver.Rows, error) {
+	s.record(args)
+	return &testRows{}, nil
+}
+
+func (s *testStmt) record(args []sqldriver.Value) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.queries = append(s.d.queries, s.query)
+	s.d.params = append(s.d.params, args)
+}
+
+func (r *testRows) Columns() []string { return []string{"id"} }
+func (r *testRows) Close() error      { return nil }
+
+func (r *testRows) Next(dest []sqldriver.Value) error {
+	if r.n++; r.n > 1 {
+		return io.EOF
+	}
+	dest[0] = int64(42)
+	return nil
+}
+
+func TestSQL_Exec(t *testing.T) {
+	db, err := sql.Open("qp_test", "")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	res, err := Exec(context.Background(), db, Format("DELETE FROM users WHERE id IN (%p)", []int{1, 2}))
+	assert.NoError(t, err)
+	n, _ := res.RowsAffected()
+	assert.Equal(t, int64(2), n)
+
+	q, p := testDB.last()
+	assert.Equal(t, `DELETE FROM users WHERE id IN ($1, $2)`, q)
+	assert.Equal(t, []sqldriver.Value{int64(1), int64(2)}, p)
+
+	_, err = Exec(context.Background(), db, Format("DELETE FROM users WHERE id = %p"))
+	assert.True(t, errors.Is(err, ErrParamNotFound))
+}
+
+func TestSQL_Query(t *testing.T) {
+	db, err := sql.Open("qp_test", "")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+	defer tx.Rollback()
+
+	rows, err := Query(context.Background(), tx, Format("SELECT id FROM users WHERE name = %p", "Tom"))
+	assert.NoError(t, err)
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		assert.NoError(t, rows.Scan(&id))
+		ids = append(ids, id)
+	}
+	assert.NoError(t, rows.Err())
+	assert.Equal(t, []int64{42}, ids)
+
+	q, p := testDB.last()
+	assert.Equal(t, `SELECT id FROM users WHERE name = $1`, q)
+	assert.Equal(t, []sqldriver.Value{"Tom"}, p)
+}
+
+func TestSQL_QueryRow(t *testing.T) {
+	db, err := sql.Open("qp_test", "")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	conn, err := db.Conn(context.Background())
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	var id int64
+	err = QueryRow(context.Background(), conn, Format("SELECT id FROM users WHERE name = %p", "Tom").Driver(MysqlDriver())).Scan(&id)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), id)
+
+	q, p := testDB.last()
+	assert.Equal(t, `SELECT id FROM users WHERE name = ?`, q)
+	assert.Equal(t, []sqldriver.Value{"Tom"}, p)
+
+	err = QueryRow(context.Background(), conn, Format("SELECT id FROM users WHERE name = %p")).Scan(&id)
+	assert.True(t, errors.Is(err, ErrParamNotFound))
+}
+
+func TestSQL_ExecAll(t *testing.T) {
+	db, err := sql.Open("qp_test", "")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+	defer tx.Rollback()
+
+	batches, err := Batch(nil, "INSERT INTO users (id) VALUES %s", MustCompile("(%p)"), [][]interface{}{{1}, {2}})
+	assert.NoError(t, err)
+	assert.NoError(t, ExecAll(context.Background(), tx, batches))
+
+	q, p := testDB.last()
+	assert.Equal(t, `INSERT INTO users (id) VALUES ($1), ($2)`, q)
+	assert.Equal(t, []sqldriver.Value{int64(1), int64(2)}, p)
+
+	err = ExecAll(context.Background(), tx, []Formatter{Format("DELETE FROM users WHERE id = %p")})
+	assert.True(t, errors.Is(err, ErrParamNotFound))
+}
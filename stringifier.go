@@ -0,0 +1,112 @@
+package qp
+
+import (
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Stringifier writes values with the %s verb
+// It is set per Formatter, see WithStringifier, or per Driver, see StringifierDriver,
+// a nested formatter is rendered with its own Stringifier if it is set, with a Stringifier of the outer formatter otherwise
+// A Stringifier is shared by concurrent renders, it must not be modified after use
+//		var s = qp.DefaultStringifier()
+//		s.Time = func(t time.Time) string { return t.UTC().Format("2006-01-02") }
+//		_ = qp.WithStringifier(qp.Format("%s", time.Now()), s).String() // 2020-01-24
+type Stringifier struct {
+	Null  string                           // written for nil
+	Bools [2]string                        // written for false and true
+	Float func(f float64, bits int) string // bits is 32 or 64
+	Time  func(t time.Time) string
+	Hooks map[reflect.Type]func(x interface{}) string // hooks by the exact type of a value, checked first
+}
+
+// DefaultStringifier returns a new Stringifier with shortest round trip floats,
+// RFC 3339 times, NULL for nil and TRUE, FALSE for bools
+func DefaultStringifier() *Stringifier {
+	return &Stringifier{
+		Null:  "NULL",
+		Bools: [2]string{"FALSE", "TRUE"},
+		Float: func(f float64, bits int) string {
+			return strconv.FormatFloat(f, 'g', -1, bits)
+		},
+		Time: func(t time.Time) string {
+			return t.Format(time.RFC3339Nano)
+		},
+	}
+}
+
+// defaultStringifier is used by drivers without the StringifierDriver interface
+var defaultStringifier = DefaultStringifier()
+
+// bitStringifier writes bools as 1 and 0 for drivers without boolean literals
+var bitStringifier = func() *Stringifier {
+	var s = DefaultStringifier()
+	s.Bools = [2]string{"0", "1"}
+	return s
+}()
+
+// WithStringifier sets a Stringifier of the %s verb to a formatter of the package and returns it,
+// other formatters are returned as is
+//		var query = qp.WithStringifier(qp.Format("%s", 0.5), s)
+func WithStringifier(f Formatter, s *Stringifier) Formatter {
+	if x, ok := f.(interface{ setStringifier(s *Stringifier) }); ok {
+		x.setStringifier(s)
+	}
+	return f
+}
+
+// Hook sets a hook for values of the same type as x
+//		s.Hook(uuid.UUID{}, func(x interface{}) string { return x.(uuid.UUID).String() })
+func (s *Stringifier) Hook(x interface{}, hook func(x interface{}) string) *Stringifier {
+	if s.Hooks == nil {
+		s.Hooks = map[reflect.Type]func(x interface{}) string{}
+	}
+	s.Hooks[reflect.TypeOf(x)] = hook
+	return s
+}
+
+// hook returns a hook for the type of x
+func (s *Stringifier) hook(x interface{}) (func(x interface{}) string, bool) {
+	if len(s.Hooks) == 0 {
+		return nil, false
+	}
+	var h, ok = s.Hooks[reflect.TypeOf(x)]
+	return h, ok
+}
+
+// float writes f, a nil Float is the default one
+func (s *Stringifier) float(f float64, bits int) string {
+	if s.Float == nil {
+		return defaultStringifier.Float(f, bits)
+	}
+	return s.Float(f, bits)
+}
+
+// bools writes b, empty Bools are the default ones
+func (s *Stringifier) bools(b bool) string {
+	if s.Bools == ([2]string{}) {
+		return defaultStringifier.Bools[btoi(b)]
+	}
+	return s.Bools[btoi(b)]
+}
+
+// time writes t, a nil Time is the default one
+func (s *Stringifier) time(t time.Time) string {
+	if s.Time == nil {
+		return defaultStringifier.Time(t)
+	}
+	return s.Time(t)
+}
+
+// stringifier returns a Stringifier of the render
+func (p *printer) stringifier() *Stringifier {
+	if p.str == nil {
+		if d, ok := p.driver.(StringifierDriver); ok {
+			p.str = d.Stringifier()
+		} else {
+			p.str = defaultStringifier
+		}
+	}
+	return p.str
+}
@@ -0,0 +1,66 @@
+package qp
+
+import (
+	"reflect"
+	"strings"
+)
+
+// field is an exported struct field
+type field struct {
+	name      string
+	omitempty bool
+	value     reflect.Value
+}
+
+// fieldsOf returns exported fields of a struct, fields of embedded structs and struct pointers are included
+// A field is named by the "db" tag, "-" skips the field
+//		Name string `db:"name,omitempty"`
+func fieldsOf(v reflect.Value) []field {
+	var (
+		t      = v.Type()
+		fields = make([]field, 0, t.NumField())
+	)
+	for i := 0; i < t.NumField(); i++ {
+		var sf = t.Field(i)
+		if sf.PkgPath != "" && !sf.Anonymous {
+			continue
+		}
+		var tag = sf.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+		var name, opts = tag, ""
+		if c := strings.IndexByte(tag, ','); c >= 0 {
+			name, opts = tag[:c], tag[c+1:]
+		}
+		if sf.Anonymous && name == "" && sf.Type.Kind() == reflect.Struct {
+			fields = append(fields, fieldsOf(v.Field(i))...)
+			continue
+		}
+		if sf.Anonymous && name == "" && sf.Type.Kind() == reflect.Ptr && sf.Type.Elem().Kind() == reflect.Struct {
+			if sf.PkgPath != "" {
+				continue
+			}
+			// a nil pointer gives zero values, so every struct of a type has the same columns
+			var e = v.Field(i)
+			if e.IsNil() {
+				fields = append(fields, fieldsOf(reflect.New(sf.Type.Elem()).Elem())...)
+			} else {
+				fields = append(fields, fieldsOf(e.Elem())...)
+			}
+			continue
+		}
+		if sf.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(sf.Name)
+		}
+		fields = append(fields, field{
+			name:      name,
+			omitempty: opts == "omitempty",
+			value:     v.Field(i),
+		})
+	}
+	return fields
+}
@@ -0,0 +1,89 @@
+package qp
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Template is a compiled format, it is parsed once and can be bound many times
+//
+//	var byName = qp.MustCompile("SELECT id FROM users WHERE name = %p LIMIT %p")
+//
+//	var query = byName.Bind("Tom", 10)
+//	_ = query.String() // SELECT id FROM users WHERE name = $1 LIMIT $2
+//	_ = query.Params() // ["Tom", 10]
+type Template struct {
+	format string
+	tokens []token
+	strict bool
+	refs   bool // parameters are referred by names or indexes
+}
+
+// Compile parses a format and returns a Template
+// It returns a *FormatError if a verb can never have a parameter,
+// for example a verb after a verb with the "+" modifier
+func Compile(format string) (*Template, error) {
+	var t = *compile(format)
+	var spread bool
+	for _, tok := range t.tokens {
+		if tok.verb == 0 || tok.name != "" || verbOf(tok.verb) == nil {
+			continue
+		}
+		if spread && tok.index == 0 {
+			return nil, &FormatError{Offset: tok.offset, Verb: tok.verb, Err: ErrParamNotFound}
+		}
+		spread = tok.spread
+	}
+	t.strict = true
+	return &t, nil
+}
+
+// MustCompile is like Compile but panics if the format is invalid
+// It simplifies initialization of global variables holding templates
+func MustCompile(format string) *Template {
+	t, err := Compile(format)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// maxTemplates is the maximum number of cached templates
+const maxTemplates = 4096
+
+// templates caches templates of compile by formats, the first maxTemplates formats are cached
+// Formats are mostly literals, so building a query usually parses nothing
+var (
+	templates      sync.Map
+	templatesCount int32
+)
+
+// compile parses a format without validation
+// A template is shared by the cache, it must not be modified
+func compile(format string) *Template {
+	if t, ok := templates.Load(format); ok {
+		return t.(*Template)
+	}
+	var t = &Template{
+		format: format,
+		tokens: parse(format),
+	}
+	for _, tok := range t.tokens {
+		t.refs = t.refs || tok.name != "" || tok.index > 0
+	}
+	if atomic.LoadInt32(&templatesCount) < maxTemplates && atomic.AddInt32(&templatesCount, 1) <= maxTemplates {
+		templates.Store(format, t)
+	}
+	return t
+}
+
+// Bind returns a Formatter of the template with parameters
+// Unlike Format, the number of parameters must match the template,
+// otherwise Build returns a *FormatError
+func (t *Template) Bind(params ...interface{}) Formatter {
+	return &formatter{
+		format: []*Template{t},
+		params: [][]interface{}{params},
+		jumper: " AND ",
+	}
+}
@@ -0,0 +1,104 @@
+package qp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplate_Bind(t *testing.T) {
+	tpl, err := Compile("SELECT id FROM table WHERE status = %p AND %s AND id IN (%+p)")
+	assert.NoError(t, err)
+
+	q := tpl.Bind("active", Format("name = %p", "Tom"), 1, 2, 3)
+	assert.Equal(t,
+		`SELECT id FROM table WHERE status = $1 AND name = $2 AND id IN ($3, $4, $5)`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{"active", "Tom", 1, 2, 3},
+		q.Params(),
+	)
+
+	q = tpl.Bind("passive", Format("1=1"), []int{4, 5})
+	assert.Equal(t,
+		`SELECT id FROM table WHERE status = $1 AND 1=1 AND id IN ($2, $3)`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{"passive", 4, 5},
+		q.Params(),
+	)
+}
+
+func TestTemplate_Nested(t *testing.T) {
+	tpl := MustCompile("name = %p")
+	q := Format(
+		"SELECT id FROM table WHERE status = %p AND %s LIMIT %p",
+		"active", tpl.Bind("Tom").Format("age = %p", 12), 10,
+	).Driver(MysqlDriver())
+	assert.Equal(t,
+		`SELECT id FROM table WHERE status = ? AND name = ? AND age = ? LIMIT ?`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{"active", "Tom", 12, 10},
+		q.Params(),
+	)
+}
+
+func TestTemplate_Errors(t *testing.T) {
+	var err error
+
+	_, err = Compile("id IN (%+p) AND name = %p")
+	assert.Equal(t, &FormatError{Offset: 23, Verb: 'p', Err: ErrParamNotFound}, err)
+	assert.Panics(t, func() { MustCompile("%+s%s") })
+
+	tpl := MustCompile("id = %p AND name = %p")
+
+	_, _, err = tpl.Bind(1).Build()
+	assert.Equal(t, &FormatError{Offset: 19, Verb: 'p', Err: ErrParamNotFound}, err)
+
+	_, _, err = tpl.Bind(1, "Tom", 12).Build()
+	assert.Equal(t, &FormatError{Offset: 21, Err: ErrTooManyParams}, err)
+	assert.EqualError(t, err, "qp: too many parameters (fragment 0, offset 21)")
+}
+
+func TestTemplate_Index(t *testing.T) {
+	tpl := MustCompile("id IN (%+p) OR parent_id IN (%[1]+p)")
+	q := tpl.Bind(1, 2)
+	assert.Equal(t,
+		`id IN ($1, $2) OR parent_id IN ($1, $2)`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{1, 2},
+		q.Params(),
+	)
+
+	tpl = MustCompile("%[2]p, %[1]p")
+	_, _, err := tpl.Bind(1, 2, 3).Build()
+	assert.Equal(t, &FormatError{Offset: 12, Err: ErrTooManyParams}, err)
+}
+
+func TestTemplate_Cache(t *testing.T) {
+	tpl := MustCompile("id = %p")
+	assert.True(t, compile("id = %p") == compile("id = %p"))
+	assert.False(t, tpl == compile("id = %p"))
+
+	// a compiled template is strict, a cached one is not
+	_, _, err := tpl.Bind(1, 2).Build()
+	assert.Equal(t, &FormatError{Offset: 7, Err: ErrTooManyParams}, err)
+	_, _, err = Format("id = %p", 1, 2).Build()
+	assert.NoError(t, err)
+}
+
+func BenchmarkTemplate_Bind(b *testing.B) {
+	var tpl = MustCompile(`SELECT id FROM table WHERE %s LIMIT %p`)
+	for i := 0; i < b.N; i++ {
+		var b = Format("name = %p", "Tom").
+			Format("age = %p", []int64{18, 21, 30})
+
+		_, _, _ = tpl.Bind(b, 10).Build()
+	}
+}
@@ -0,0 +1,130 @@
+package qp
+
+import (
+	"reflect"
+	"sort"
+)
+
+// UpdateBuilder builds an UPDATE statement from structs or maps
+type UpdateBuilder struct {
+	table string
+	skip  map[string]bool
+	set   *formatter
+	err   error
+}
+
+// updateSet is a template of a column assignment
+// updateExpr is a template of a column assignment with a raw expression
+var (
+	updateSet  = compile("%i = %p")
+	updateExpr = compile("%i = %s")
+)
+
+// Update returns a builder of an UPDATE statement of the table
+// Columns are assigned in the order of struct fields or in the order of sorted map keys,
+// a Formatter value is written as a raw expression
+//		var query = qp.Update("users").
+//			Set(map[string]interface{}{"name": "Tom", "visits": qp.Format("visits + %p", 1)}).
+//			Where(qp.Format("id = %p", 7))
+//		_ = query.String() // UPDATE "users" SET "name" = $1, "visits" = visits + $2 WHERE id = $3
+//		_ = query.Params() // ["Tom", 1, 7]
+func Update(table string) *UpdateBuilder {
+	return &UpdateBuilder{
+		table: table,
+		skip:  map[string]bool{},
+		set:   New().Jumper(", ").(*formatter),
+	}
+}
+
+// Skip skips columns which must not be updated, for example primary keys
+// It affects columns set after the call
+func (b *UpdateBuilder) Skip(columns ...string) *UpdateBuilder {
+	for _, c := range columns {
+		b.skip[c] = true
+	}
+	return b
+}
+
+// Set sets columns of a struct or of a map with string keys, pointers are allowed
+// Columns are taken from exported fields with "db" tags, see FormatNamed,
+// a column with the "omitempty" option is skipped if it is empty
+func (b *UpdateBuilder) Set(values interface{}) *UpdateBuilder {
+	var v = reflect.Indirect(reflect.ValueOf(values))
+	switch {
+	case v.Kind() == reflect.Struct:
+		for _, f := range fieldsOf(v) {
+			if !f.omitempty || !f.value.IsZero() {
+				b.assign(f.name, f.value.Interface())
+			}
+		}
+	case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+		var keys = v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return keys[i].String() < keys[j].String()
+		})
+		for _, k := range keys {
+			b.assign(k.String(), v.MapIndex(k).Interface())
+		}
+	default:
+		if b.err == nil {
+			b.err = unsupported(values)
+		}
+	}
+	return b
+}
+
+// Changed sets columns of the struct after which differ from the struct before,
+// both structs must be of the same type, pointers are allowed
+// The "omitempty" option is ignored, a column changed to an empty value is set too
+//		var before, after = user, user
+//		after.Name = "Huck"
+//		_ = qp.Update("users").Changed(before, after).Where(qp.Format("id = %p", user.ID)).String()
+//		// UPDATE "users" SET "name" = $1 WHERE id = $2
+func (b *UpdateBuilder) Changed(before, after interface{}) *UpdateBuilder {
+	var (
+		x = reflect.Indirect(reflect.ValueOf(before))
+		y = reflect.Indirect(reflect.ValueOf(after))
+	)
+	if x.Kind() != reflect.Struct || y.Kind() != reflect.Struct || x.Type() != y.Type() {
+		if b.err == nil {
+			b.err = unsupported(after)
+		}
+		return b
+	}
+	var old = fieldsOf(x)
+	for i, f := range fieldsOf(y) {
+		var v = f.value.Interface()
+		if !reflect.DeepEqual(old[i].value.Interface(), v) {
+			b.assign(f.name, v)
+		}
+	}
+	return b
+}
+
+// assign appends an assignment of the column
+func (b *UpdateBuilder) assign(column string, x interface{}) {
+	if b.skip[column] {
+		return
+	}
+	if _, ok := x.(Formatter); ok {
+		b.set.bind(updateExpr, []interface{}{column, x})
+		return
+	}
+	b.set.bind(updateSet, []interface{}{column, scalar{x}})
+}
+
+// Where returns a Formatter of the UPDATE statement with the condition
+// A nil condition updates every row
+func (b *UpdateBuilder) Where(where Formatter) Formatter {
+	var err = b.err
+	if err == nil && len(b.set.format) == 0 {
+		err = ErrNoColumns
+	}
+	if err != nil {
+		return Format("%s", &failed{err: err})
+	}
+	if where == nil {
+		return Format("UPDATE %i SET %s", b.table, b.set)
+	}
+	return Format("UPDATE %i SET %s WHERE %s", b.table, b.set, where)
+}
@@ -0,0 +1,99 @@
+package qp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdate_Set(t *testing.T) {
+	q := Update("users").
+		Set(map[string]interface{}{"name": "Tom", "visits": Format("visits + %p", 1), "tags": []string{"a", "b"}}).
+		Where(Format("id = %p", 7))
+	assert.Equal(t,
+		`UPDATE "users" SET "name" = $1, "tags" = $2, "visits" = visits + $3 WHERE id = $4`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{"Tom", []string{"a", "b"}, 1, 7},
+		q.Params(),
+	)
+
+	q = Update("users").Skip("id", "created_at").Set(&testUser{ID: 7, Name: "Tom", Comment: "skipped"}).Where(nil)
+	assert.Equal(t,
+		`UPDATE "users" SET "name" = $1, "tags" = $2`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{"Tom", []string(nil)},
+		q.Params(),
+	)
+}
+
+func TestUpdate_SetEmbeddedPtr(t *testing.T) {
+	type Base struct {
+		ID int64 `db:"id"`
+	}
+	type User struct {
+		*Base
+		Name string `db:"name"`
+	}
+
+	q := Update("users").Skip("id").Set(User{Base: &Base{ID: 7}, Name: "Tom"}).Where(Format("id = %p", 7))
+	assert.Equal(t, `UPDATE "users" SET "name" = $1 WHERE id = $2`, q.String())
+	assert.Equal(t, []interface{}{"Tom", 7}, q.Params())
+}
+
+func TestUpdate_Drivers(t *testing.T) {
+	var cases = []struct {
+		driver Driver
+		query  string
+	}{
+		{driver: PgsqlDriver(), query: `UPDATE "users" SET "age" = $1, "name" = $2 WHERE id = $3`},
+		{driver: MysqlDriver(), query: "UPDATE `users` SET `age` = ?, `name` = ? WHERE id = ?"},
+		{driver: SqliteDriver(), query: `UPDATE "users" SET "age" = ?, "name" = ? WHERE id = ?`},
+		{driver: SqlserverDriver(), query: `UPDATE [users] SET [age] = @p1, [name] = @p2 WHERE id = @p3`},
+		{driver: OracleDriver(), query: `UPDATE "USERS" SET "AGE" = :1, "NAME" = :2 WHERE id = :3`},
+	}
+	for _, c := range cases {
+		q := Update("users").Set(map[string]int{"name": 1, "age": 2}).Where(Format("id = %p", 7)).Driver(c.driver)
+		assert.Equal(t, c.query, q.String())
+		assert.Equal(t, []interface{}{2, 1, 7}, q.Params())
+	}
+}
+
+func TestUpdate_Changed(t *testing.T) {
+	var before = testUser{ID: 7, Name: "Tom", Age: 12}
+	var after = before
+	after.Name, after.Age = "Huck", 0
+
+	q := Update("users").Changed(before, &after).Where(Format("id = %p", after.ID))
+	assert.Equal(t,
+		`UPDATE "users" SET "name" = $1, "age" = $2 WHERE id = $3`,
+		q.String(),
+	)
+	assert.Equal(t,
+		[]interface{}{"Huck", 0, int64(7)},
+		q.Params(),
+	)
+}
+
+func TestUpdate_Errors(t *testing.T) {
+	var err error
+
+	_, _, err = Update("users").Where(nil).Build()
+	assert.True(t, errors.Is(err, ErrNoColumns))
+
+	_, _, err = Update("users").Changed(testUser{}, testUser{}).Where(nil).Build()
+	assert.True(t, errors.Is(err, ErrNoColumns))
+
+	_, _, err = Update("users").Set(1).Where(nil).Build()
+	assert.True(t, errors.Is(err, ErrUnsupportedValue))
+
+	_, _, err = Update("users").Set(map[int]int{1: 1}).Where(nil).Build()
+	assert.True(t, errors.Is(err, ErrUnsupportedValue))
+
+	_, _, err = Update("users").Changed(testUser{}, &struct{}{}).Where(nil).Build()
+	assert.True(t, errors.Is(err, ErrUnsupportedValue))
+}
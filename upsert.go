@@ -0,0 +1,102 @@
+package qp
+
+// Upsert is an INSERT statement which resolves conflicts on a unique key, see Upserter
+type Upsert struct {
+	Table    string
+	Columns  []string  // inserted columns
+	Values   Formatter // rows of values joined by ", "
+	Conflict []string  // columns of the unique key
+	Update   []string  // columns updated on a conflict, no columns means do nothing
+}
+
+// upsert is a dialect specific upsert statement
+type upsert struct {
+	u Upsert
+}
+
+func (x *upsert) print(p *printer) error {
+	if d, ok := p.driver.(Upserter); ok {
+		return p.text(d.Upsert(&x.u))
+	}
+	var u = &x.u
+	if len(u.Update) == 0 {
+		if len(u.Conflict) == 0 {
+			return p.text(Format("INSERT INTO %i (%i) VALUES %s ON CONFLICT DO NOTHING", u.Table, u.Columns, u.Values))
+		}
+		return p.text(Format("INSERT INTO %i (%i) VALUES %s ON CONFLICT (%i) DO NOTHING", u.Table, u.Columns, u.Values, u.Conflict))
+	}
+	if len(u.Conflict) == 0 {
+		return ErrNoColumns
+	}
+	return p.text(Format("INSERT INTO %i (%i) VALUES %s ON CONFLICT (%i) DO UPDATE SET %s",
+		u.Table, u.Columns, u.Values, u.Conflict, assignments("%i = EXCLUDED.%i", ", ", u.Update)))
+}
+
+// assignments returns the format with every column as both parameters joined by the jumper
+//		assignments("%i = VALUES(%i)", ", ", []string{"a", "b"}) => "a" = VALUES("a"), "b" = VALUES("b")
+func assignments(format, jumper string, columns []string) Formatter {
+	var (
+		t = compile(format)
+		f = New().Jumper(jumper).(*formatter)
+	)
+	for _, c := range columns {
+		f.bind(t, []interface{}{c, c})
+	}
+	return f
+}
+
+// OnConflict makes the statement an upsert on conflicts of the unique key of the columns,
+// conflicting rows are skipped unless DoUpdate is called
+// The statement is rendered for the driver:
+//		postgresql, sqlite: INSERT ... ON CONFLICT (...) DO UPDATE SET "x" = EXCLUDED."x" or DO NOTHING
+//		mysql: INSERT ... ON DUPLICATE KEY UPDATE `x` = VALUES(`x`) or INSERT IGNORE ...
+//		sql server, oracle: MERGE ...
+//
+//		var query = qp.Insert("users").OnConflict("id").DoUpdate("name").Rows(users)
+//		_ = query.String() // INSERT INTO "users" ("id", "name") VALUES ($1, $2) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name"
+func (b *InsertBuilder) OnConflict(columns ...string) *InsertBuilder {
+	b.upsert = true
+	b.conflict = columns
+	return b
+}
+
+// DoUpdate updates the columns of conflicting rows with the inserted values,
+// no columns means every inserted column except columns of the unique key
+func (b *InsertBuilder) DoUpdate(columns ...string) *InsertBuilder {
+	b.upsert = true
+	b.update = columns
+	b.updateAll = len(columns) == 0
+	return b
+}
+
+// DoNothing skips conflicting rows
+func (b *InsertBuilder) DoNothing() *InsertBuilder {
+	b.upsert = true
+	b.update = nil
+	b.updateAll = false
+	return b
+}
+
+// upsertOf returns the upsert statement of the builder
+func (b *InsertBuilder) upsertOf(columns []string, values Formatter) Formatter {
+	var update = b.update
+	if b.updateAll {
+		var conflict = make(map[string]bool, len(b.conflict))
+		for _, c := range b.conflict {
+			conflict[c] = true
+		}
+		update = make([]string, 0, len(columns))
+		for _, c := range columns {
+			if !conflict[c] {
+				update = append(update, c)
+			}
+		}
+	}
+	return Format("%s", &upsert{u: Upsert{
+		Table:    b.table,
+		Columns:  columns,
+		Values:   values,
+		Conflict: b.conflict,
+		Update:   update,
+	}})
+}
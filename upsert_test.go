@@ -0,0 +1,105 @@
+package qp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testAccount struct {
+	ID   int64  `db:"id"`
+	Name string `db:"name"`
+	Age  int    `db:"age"`
+}
+
+func TestInsert_Upsert(t *testing.T) {
+	var rows = []testAccount{{ID: 1, Name: "Tom", Age: 12}, {ID: 2, Name: "Huck", Age: 13}}
+
+	var cases = []struct {
+		driver Driver
+		update string
+		ignore string
+	}{
+		{
+			driver: PgsqlDriver(),
+			update: `INSERT INTO "users" ("id", "name", "age") VALUES ($1, $2, $3), ($4, $5, $6) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name", "age" = EXCLUDED."age"`,
+			ignore: `INSERT INTO "users" ("id", "name", "age") VALUES ($1, $2, $3), ($4, $5, $6) ON CONFLICT ("id") DO NOTHING`,
+		},
+		{
+			driver: SqliteDriver(),
+			update: `INSERT INTO "users" ("id", "name", "age") VALUES (?, ?, ?), (?, ?, ?) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name", "age" = EXCLUDED."age"`,
+			ignore: `INSERT INTO "users" ("id", "name", "age") VALUES (?, ?, ?), (?, ?, ?) ON CONFLICT ("id") DO NOTHING`,
+		},
+		{
+			driver: MysqlDriver(),
+			update: "INSERT INTO `users` (`id`, `name`, `age`) VALUES (?, ?, ?), (?, ?, ?) ON DUPLICATE KEY UPDATE `name` = VALUES(`name`), `age` = VALUES(`age`)",
+			ignore: "INSERT IGNORE INTO `users` (`id`, `name`, `age`) VALUES (?, ?, ?), (?, ?, ?)",
+		},
+		{
+			driver: SqlserverDriver(),
+			update: `MERGE INTO [users] WITH (HOLDLOCK) AS target USING (VALUES (@p1, @p2, @p3), (@p4, @p5, @p6)) AS source ([id], [name], [age]) ON target.[id] = source.[id]` +
+				` WHEN MATCHED THEN UPDATE SET target.[name] = source.[name], target.[age] = source.[age]` +
+				` WHEN NOT MATCHED THEN INSERT ([id], [name], [age]) VALUES (source.[id], source.[name], source.[age]);`,
+			ignore: `MERGE INTO [users] WITH (HOLDLOCK) AS target USING (VALUES (@p1, @p2, @p3), (@p4, @p5, @p6)) AS source ([id], [name], [age]) ON target.[id] = source.[id]` +
+				` WHEN NOT MATCHED THEN INSERT ([id], [name], [age]) VALUES (source.[id], source.[name], source.[age]);`,
+		},
+		{
+			driver: OracleDriver(),
+			update: `MERGE INTO "USERS" target USING (SELECT :1 "ID", :2 "NAME", :3 "AGE" FROM dual UNION ALL SELECT :4 "ID", :5 "NAME", :6 "AGE" FROM dual) source ON (target."ID" = source."ID")` +
+				` WHEN MATCHED THEN UPDATE SET target."NAME" = source."NAME", target."AGE" = source."AGE"` +
+				` WHEN NOT MATCHED THEN INSERT ("ID", "NAME", "AGE") VALUES (source."ID", source."NAME", source."AGE")`,
+			ignore: `MERGE INTO "USERS" target USING (SELECT :1 "ID", :2 "NAME", :3 "AGE" FROM dual UNION ALL SELECT :4 "ID", :5 "NAME", :6 "AGE" FROM dual) source ON (target."ID" = source."ID")` +
+				` WHEN NOT MATCHED THEN INSERT ("ID", "NAME", "AGE") VALUES (source."ID", source."NAME", source."AGE")`,
+		},
+	}
+	var params = []interface{}{int64(1), "Tom", 12, int64(2), "Huck", 13}
+	for _, c := range cases {
+		q := Insert("users").OnConflict("id").DoUpdate().Rows(rows).Driver(c.driver)
+		assert.Equal(t, c.update, q.String())
+		assert.Equal(t, params, q.Params())
+
+		q = Insert("users").OnConflict("id").Rows(rows).Driver(c.driver)
+		assert.Equal(t, c.ignore, q.String())
+		assert.Equal(t, params, q.Params())
+	}
+}
+
+func TestInsert_UpsertColumns(t *testing.T) {
+	q := Insert("users").OnConflict("id").DoUpdate("name").Rows(testAccount{ID: 1, Name: "Tom"})
+	assert.Equal(t,
+		`INSERT INTO "users" ("id", "name", "age") VALUES ($1, $2, $3) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name"`,
+		q.String(),
+	)
+
+	q = Insert("users").DoNothing().Rows(testAccount{ID: 1, Name: "Tom"})
+	assert.Equal(t,
+		`INSERT INTO "users" ("id", "name", "age") VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`,
+		q.String(),
+	)
+
+	batches, err := Insert("users").OnConflict("id").DoUpdate("name").Batches(testLimitDriver{limit: 3}, []testAccount{{ID: 1}, {ID: 2}})
+	assert.NoError(t, err)
+	assert.Len(t, batches, 2)
+	assert.Equal(t,
+		`INSERT INTO "users" ("id", "name", "age") VALUES (?, ?, ?) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name"`,
+		batches[1].String(),
+	)
+	assert.Equal(t, []interface{}{int64(2), "", 0}, batches[1].Params())
+}
+
+func TestInsert_UpsertErrors(t *testing.T) {
+	var err error
+
+	_, _, err = Insert("users").DoUpdate("name").Rows(testAccount{}).Build()
+	assert.True(t, errors.Is(err, ErrNoColumns))
+
+	_, _, err = Insert("users").DoNothing().Rows(testAccount{}).Driver(SqlserverDriver()).Build()
+	assert.True(t, errors.Is(err, ErrNoColumns))
+
+	_, _, err = Insert("users").DoNothing().Rows(testAccount{}).Driver(OracleDriver()).Build()
+	assert.True(t, errors.Is(err, ErrNoColumns))
+
+	_, _, err = Format("%s", &upsert{u: Upsert{Table: "users", Columns: []string{"id", "name"}, Values: Format("(%p)", 1), Conflict: []string{"id"}}}).Driver(OracleDriver()).Build()
+	assert.True(t, errors.Is(err, ErrUnsupportedValue))
+}
@@ -1,11 +1,86 @@
 package qp
 
 import (
+	"database/sql"
+	sqldriver "database/sql/driver"
+	"reflect"
 	"strconv"
 	"strings"
+	"time"
 	"unsafe"
 )
 
+// The repeated a helper function returns count(x) same placeholders
+// For example: repeated("?", []int{1, 2, 3}) => "?, ?, ?"
+func repeated(placeholder string, x interface{}) string {
+	var n int
+	switch n = count(x); n {
+	case 0:
+		return ""
+	case 1:
+		return placeholder
+	}
+
+	var (
+		b = make([]byte, (len(placeholder)+2)*n)
+		w = copy(b, ", ")
+	)
+	w += copy(b[w:], placeholder)
+
+	for w < len(b) {
+		copy(b[w:], b[:w])
+		w *= 2
+	}
+	b = b[2:]
+
+	return *(*string)(unsafe.Pointer(&b))
+}
+
+// The appendRepeated a helper function appends count(x) same placeholders to b
+// For example: appendRepeated([]byte("id IN ("), "?", []int{1, 2}) => "id IN (?, ?"
+func appendRepeated(b []byte, placeholder string, x interface{}) []byte {
+	for i, c := 0, count(x); i < c; i++ {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		b = append(b, placeholder...)
+	}
+	return b
+}
+
+// The numbered a helper function returns count(x) placeholders numbered from n+1
+// For example: numbered("$", 2, []int{1, 2}) => "$3, $4"
+func numbered(prefix string, n int, x interface{}) string {
+	var c int
+	switch c = count(x); c {
+	case 0:
+		return ""
+	case 1:
+		return prefix + strconv.Itoa(n+1)
+	}
+
+	var cap = (len(", ")+len(prefix))*(c-1) + len(prefix)
+	for i := 1; i <= c; i++ {
+		cap += intWeight(n + i)
+	}
+
+	var b = appendNumbered(make([]byte, 0, cap), prefix, n, c)
+	return *(*string)(unsafe.Pointer(&b))
+}
+
+// The appendNumbered a helper function appends c placeholders numbered from n+1 to b
+// For example: appendNumbered([]byte("id IN ("), "$", 2, 2) => "id IN ($3, $4"
+func appendNumbered(b []byte, prefix string, n int, c int) []byte {
+	for i := 1; i <= c; i++ {
+		if i > 1 {
+			b = append(b, ", "...)
+		}
+		b = append(b, prefix...)
+		b = strconv.AppendInt(b, int64(n+i), 10)
+	}
+	return b
+}
+
 // IntWeight returns number of digits in an int
 func intWeight(x int) int {
 	var p = 10
@@ -66,6 +141,21 @@ func stringsToString(x []string) string {
 	return b.String()
 }
 
+// The quote a helper function wraps s with quotes and doubles closing quotes inside
+// For example: quote(`my "table"`, '"', '"') => `"my ""table"""`
+func quote(s string, open, close byte) string {
+	var b = make([]byte, 0, len(s)+2)
+	b = append(b, open)
+	for i := 0; i < len(s); i++ {
+		if s[i] == close {
+			b = append(b, close)
+		}
+		b = append(b, s[i])
+	}
+	b = append(b, close)
+	return *(*string)(unsafe.Pointer(&b))
+}
+
 // The btoi a helper function converts bool to int
 func btoi(b bool) int {
 	switch b {
@@ -91,28 +181,56 @@ func count(x interface{}) int {
 			n += count(x)
 		}
 		return n
+	case scalar:
+		return 1
 	default:
+		if xs, ok := expand(x); ok {
+			return count(xs)
+		}
 		return 1
 	}
 }
 
-// The filters a helper function filters and appends only Formatter elements to the end of a slice params
-func filters(params []interface{}, args ...interface{}) []interface{} {
-	for _, x := range args {
-		switch x := x.(type) {
-		case Formatter:
-			params = append(params, x.Params()...)
-		case []interface{}:
-			params = filters(params, x...)
-		}
+// The expand a helper function returns elements of a collection which is not one of the fast path types
+// A slice or an array is expanded, unless it is a []byte like value or a driver.Valuer,
+// an Expander decides by itself
+func expand(x interface{}) ([]interface{}, bool) {
+	switch x := x.(type) {
+	case nil, string, []byte, bool, time.Time,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		float32, float64:
+		// common scalars skip the reflection
+		return nil, false
+	case Expander:
+		return x.Expand()
+	case sqldriver.Valuer:
+		return nil, false
 	}
-	return params
+	var v = reflect.ValueOf(x)
+	if (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) || v.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+	var xs = make([]interface{}, v.Len())
+	for i := range xs {
+		xs[i] = v.Index(i).Interface()
+	}
+	return xs, true
+}
+
+// scalar is a single parameter which is never expanded, even if it is a slice
+type scalar struct {
+	x interface{}
 }
 
 // The insert a helper function appends elements to the end of a slice params
 func insert(params []interface{}, args ...interface{}) []interface{} {
 	for _, x := range args {
 		switch x := x.(type) {
+		case scalar:
+			params = append(params, x.x)
+		case sql.NamedArg:
+			params = append(params, x.Value)
 		case []int:
 			for _, x := range x {
 				params = append(params, x)
@@ -128,7 +246,11 @@ func insert(params []interface{}, args ...interface{}) []interface{} {
 		case []interface{}:
 			params = insert(params, x...)
 		default:
-			params = append(params, x)
+			if xs, ok := expand(x); ok {
+				params = insert(params, xs...)
+			} else {
+				params = append(params, x)
+			}
 		}
 	}
 	return params
@@ -0,0 +1,103 @@
+package qp
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// VerbFunc writes a verb with its parameter
+// With the "+" modifier the parameter is a []interface{} of all remaining parameters
+type VerbFunc func(w *Writer, arg interface{}) error
+
+// Writer writes text and parameters of a verb into the query being rendered
+// It is the printer of the render, so a verb allocates nothing to get it
+type Writer printer
+
+// verbTable holds registered verbs by their letters
+type verbTable [256]VerbFunc
+
+// verbs holds a *verbTable of registered verbs, it is replaced on registration
+// It is initialized by an expression, so package templates are compiled after it
+var verbs = func() *atomic.Value {
+	var v = new(atomic.Value)
+	v.Store(&verbTable{
+		's': func(w *Writer, arg interface{}) error {
+			return w.Text(arg)
+		},
+		'p': func(w *Writer, arg interface{}) error {
+			return w.Placeholder(arg)
+		},
+		'i': func(w *Writer, arg interface{}) error {
+			return w.Ident(arg)
+		},
+		'l': func(w *Writer, arg interface{}) error {
+			return w.Literal(arg)
+		},
+		'a': func(w *Writer, arg interface{}) error {
+			if _, ok := arg.(Array); !ok {
+				arg = Array{Slice: arg}
+			}
+			return w.Placeholder(scalar{arg})
+		},
+	})
+	return v
+}()
+
+// RegisterVerb registers a verb, the built-in verbs s, p, i, l and a are registered the same way
+// and can be replaced
+// A verb is an ASCII letter, it panics otherwise
+// Verbs are looked up when a query is built, so templates compiled before registration use the verb,
+// a verb which is not registered is written as is
+//		qp.RegisterVerb('j', func(w *qp.Writer, arg interface{}) error {
+//			var path = arg.([]string)
+//			w.WriteString("#> ")
+//			return w.Placeholder(qp.Array{Slice: path})
+//		})
+//
+//		var query = qp.Format("SELECT data %j FROM users", []string{"a", "b"})
+//		_ = query.String() // SELECT data #> $1 FROM users
+func RegisterVerb(verb rune, fn VerbFunc) {
+	if verb > 'z' || !isLetter(byte(verb)) {
+		panic(fmt.Sprintf("qp: invalid verb %q", verb))
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	var t = *verbs.Load().(*verbTable)
+	t[byte(verb)] = fn
+	verbs.Store(&t)
+}
+
+// verbOf returns a registered verb
+func verbOf(verb byte) VerbFunc {
+	return verbs.Load().(*verbTable)[verb]
+}
+
+// Driver returns the Driver of the query
+func (w *Writer) Driver() Driver {
+	return w.driver
+}
+
+// WriteString writes s as is
+func (w *Writer) WriteString(s string) {
+	w.buf = append(w.buf, s...)
+}
+
+// Text writes x like the %s verb, a nested Formatter writes its own query string and parameters
+func (w *Writer) Text(x interface{}) error {
+	return (*printer)(w).text(x)
+}
+
+// Placeholder writes placeholders for x like the %p verb and appends x to parameters
+func (w *Writer) Placeholder(x interface{}) error {
+	return (*printer)(w).placeholder(x)
+}
+
+// Ident writes x as a quoted identifier like the %i verb
+func (w *Writer) Ident(x interface{}) error {
+	return (*printer)(w).ident(x)
+}
+
+// Literal writes x as an escaped sql literal like the %l verb
+func (w *Writer) Literal(x interface{}) error {
+	return (*printer)(w).literal(x)
+}
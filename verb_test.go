@@ -0,0 +1,89 @@
+package qp
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterVerb(t *testing.T) {
+	var restore = verbs.Load()
+	defer verbs.Store(restore)
+
+	var errShard = errors.New("no shard")
+
+	// %T writes a table name with a shard suffix
+	RegisterVerb('T', func(w *Writer, arg interface{}) error {
+		var x, ok = arg.([]interface{})
+		if !ok || len(x) != 2 {
+			return errShard
+		}
+		return w.Ident(fmt.Sprintf("%s_%02d", x[0], x[1]))
+	})
+	// %j writes a json path operator with the path as one parameter, the operator depends on the driver
+	RegisterVerb('j', func(w *Writer, arg interface{}) error {
+		if _, ok := w.Driver().(Numbered); ok {
+			w.WriteString("#> ")
+			return w.Placeholder(Array{Slice: arg})
+		}
+		w.WriteString("-> ")
+		return w.Text(Format("%p", arg))
+	})
+
+	q := Format("SELECT data %j FROM %+T", []string{"a", "b"}, "users", 7)
+	assert.Equal(t, `SELECT data #> $1 FROM "users_07"`, q.String())
+	assert.Equal(t, []interface{}{Array{Slice: []string{"a", "b"}}}, q.Params())
+
+	q = Format("SELECT data %j FROM users", "$.a").Driver(MysqlDriver())
+	assert.Equal(t, "SELECT data -> ? FROM users", q.String())
+	assert.Equal(t, []interface{}{"$.a"}, q.Params())
+
+	q = FormatNamed("SELECT data %{path}j, %{path}j FROM users", map[string]interface{}{"path": []string{"a"}})
+	assert.Equal(t, `SELECT data #> $1, #> $1 FROM users`, q.String())
+	assert.Equal(t, []interface{}{Array{Slice: []string{"a"}}}, q.Params())
+
+	_, _, err := Format("SELECT id FROM %T", "users").Build()
+	assert.Equal(t, &FormatError{Offset: 15, Verb: 'T', Err: errShard}, err)
+
+	assert.Panics(t, func() { RegisterVerb('1', nil) })
+	assert.Panics(t, func() { RegisterVerb('ж', nil) })
+}
+
+func TestRegisterVerb_Builtin(t *testing.T) {
+	var restore = verbs.Load()
+	defer verbs.Store(restore)
+
+	RegisterVerb('s', func(w *Writer, arg interface{}) error {
+		w.WriteString("<")
+		if err := w.Text(arg); err != nil {
+			return err
+		}
+		w.WriteString(">")
+		return nil
+	})
+	q := Format("SELECT %s FROM %s WHERE %s AND name = %p", "id", "users", Format("id = %p", 1), "Tom")
+	assert.Equal(t, `SELECT <id> FROM <users> WHERE <id = $1> AND name = $2`, q.String())
+	assert.Equal(t, []interface{}{1, "Tom"}, q.Params())
+}
+
+func TestRegisterVerb_AfterCompile(t *testing.T) {
+	var restore = verbs.Load()
+	defer verbs.Store(restore)
+
+	var tpl = MustCompile("SELECT id FROM users WHERE %Q")
+	RegisterVerb('Q', func(w *Writer, arg interface{}) error {
+		w.WriteString("id = ")
+		return w.Placeholder(arg)
+	})
+	s, params, err := tpl.Bind(1).Build()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT id FROM users WHERE id = $1`, s)
+	assert.Equal(t, []interface{}{1}, params)
+}
+
+func TestRegisterVerb_Unknown(t *testing.T) {
+	q := Format("SELECT id FROM users WHERE name LIKE 'a%d' AND id = %p", 1)
+	assert.Equal(t, `SELECT id FROM users WHERE name LIKE 'a%d' AND id = $1`, q.String())
+}